@@ -0,0 +1,56 @@
+package bitstring
+
+import "fmt"
+
+// maxBCDDigits is the maximum number of BCD digits that always fit in a
+// uint64.
+const maxBCDDigits = 19
+
+// BCD interprets the 4*digits bits at offset off as a binary-coded decimal
+// number in big endian, that is a sequence of 4-bit decimal digits, the most
+// significant digit having the highest offset, and returns its value. If a
+// digit is greater than 9, an error wrapping ErrInvalidBCD is returned.
+// Behavior is undefined if there aren't enough bits. Panics if digits is not
+// in [1, 19].
+func (bs *Bitstring) BCD(off, digits int) (uint64, error) {
+	mustBCDDigits(digits)
+
+	var val uint64
+	for i := digits - 1; i >= 0; i-- {
+		d := bs.Uintn(off+4*i, 4)
+		if d > 9 {
+			return 0, fmt.Errorf("%w: digit %#x at offset %d", ErrInvalidBCD, d, off+4*i)
+		}
+		val = val*10 + d
+	}
+	return val, nil
+}
+
+// SetBCD sets the 4*digits bits at offset off with val encoded as a
+// binary-coded decimal number, as described in BCD. If val has more than
+// digits decimal digits, bs is left untouched and an error wrapping
+// ErrOverflow is returned. Behavior is undefined if there aren't enough bits.
+// Panics if digits is not in [1, 19].
+func (bs *Bitstring) SetBCD(off, digits int, val uint64) error {
+	mustBCDDigits(digits)
+
+	max := uint64(1)
+	for i := 0; i < digits; i++ {
+		max *= 10
+	}
+	if val >= max {
+		return fmt.Errorf("%w: %d doesn't fit in %d BCD digits", ErrOverflow, val, digits)
+	}
+
+	for i := 0; i < digits; i++ {
+		bs.SetUintn(off+4*i, 4, val%10)
+		val /= 10
+	}
+	return nil
+}
+
+func mustBCDDigits(digits int) {
+	if digits < 1 || digits > maxBCDDigits {
+		panic("BCD supports numbers from 1 to 19 digits long")
+	}
+}
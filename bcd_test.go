@@ -0,0 +1,60 @@
+package bitstring
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBCD(t *testing.T) {
+	tests := []struct {
+		digits int
+		val    uint64
+		str    string
+	}{
+		{digits: 1, val: 0, str: "0000"},
+		{digits: 1, val: 9, str: "1001"},
+		{digits: 2, val: 42, str: "01000010"},
+		{digits: 4, val: 1234, str: "0001001000110100"},
+		{digits: 4, val: 7, str: "0000000000000111"},
+		{digits: 19, val: 9999999999999999999, str: "1001100110011001100110011001100110011001100110011001100110011001100110011001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.val), func(t *testing.T) {
+			for _, off := range []int{0, 3, 61} {
+				bs := New(off + 4*tt.digits + 5)
+				assert.NoError(t, bs.SetBCD(off, tt.digits, tt.val))
+				want, _ := NewFromString(tt.str)
+				assert.True(t, EqualRangeAt(bs, off, want, 0, want.Len()))
+
+				got, err := bs.BCD(off, tt.digits)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.val, got)
+			}
+		})
+	}
+}
+
+func TestBCDErrors(t *testing.T) {
+	bs, _ := NewFromString("0001101000110100") // second digit is 0xa
+	_, err := bs.BCD(0, 4)
+	assert.ErrorIs(t, err, ErrInvalidBCD)
+	assert.EqualError(t, err, "bitstring: invalid BCD digit: digit 0xa at offset 8")
+
+	// Only the read digits are checked.
+	v, err := bs.BCD(0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(34), v)
+
+	bs = New(20)
+	bs.SetRange(0, 20)
+	assert.ErrorIs(t, bs.SetBCD(2, 3, 1000), ErrOverflow)
+	assert.ErrorIs(t, bs.SetBCD(0, 4, math.MaxUint64), ErrOverflow)
+	assert.Equal(t, 20, bs.OnesCount(), "bs is left untouched")
+
+	assert.Panics(t, func() { New(100).BCD(0, 0) })
+	assert.Panics(t, func() { New(100).SetBCD(0, 20, 0) })
+}
@@ -1,6 +1,7 @@
 package bitstring
 
 import (
+	"fmt"
 	"math/rand"
 	"testing"
 )
@@ -221,3 +222,111 @@ func Benchmark_msb(b *testing.B) {
 
 	sink = val
 }
+
+func BenchmarkRotateLeft(b *testing.B) {
+	rng := rand.New(rand.NewSource(99))
+	bs := Random(4099, rng)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bs.RotateLeft(i % 4099)
+	}
+	b.StopTimer()
+	sink = bs
+}
+
+// stringPerBit and newFromStringPerBit are the bit-by-bit implementations of
+// String and NewFromString, kept as baselines for the benchmarks.
+func stringPerBit(bs *Bitstring) string {
+	b := make([]byte, bs.length)
+	for i := 0; i < bs.length; i++ {
+		if bs.Bit(i) {
+			b[bs.length-1-i] = '1'
+		} else {
+			b[bs.length-1-i] = '0'
+		}
+	}
+	return string(b)
+}
+
+func newFromStringPerBit(s string) (*Bitstring, error) {
+	bs := New(len(s))
+	for i, c := range s {
+		switch c {
+		case '0':
+			continue
+		case '1':
+			bs.SetBit(len(s) - i - 1)
+		default:
+			return nil, fmt.Errorf("illegal character at position %v: %#U", i, c)
+		}
+	}
+	return bs, nil
+}
+
+var textLengths = []int{64, 1024, 65536}
+
+func BenchmarkString(b *testing.B) {
+	rng := rand.New(rand.NewSource(99))
+	for _, length := range textLengths {
+		bs := Random(length, rng)
+		b.Run(fmt.Sprintf("len=%d/per-bit", length), func(b *testing.B) {
+			b.SetBytes(int64(length))
+			b.ReportAllocs()
+			var s string
+			for i := 0; i < b.N; i++ {
+				s = stringPerBit(bs)
+			}
+			b.StopTimer()
+			sink = s
+		})
+		b.Run(fmt.Sprintf("len=%d/String", length), func(b *testing.B) {
+			b.SetBytes(int64(length))
+			b.ReportAllocs()
+			var s string
+			for i := 0; i < b.N; i++ {
+				s = bs.String()
+			}
+			b.StopTimer()
+			sink = s
+		})
+		b.Run(fmt.Sprintf("len=%d/AppendText", length), func(b *testing.B) {
+			b.SetBytes(int64(length))
+			b.ReportAllocs()
+			buf := make([]byte, 0, length)
+			for i := 0; i < b.N; i++ {
+				buf, _ = bs.AppendText(buf[:0])
+			}
+			b.StopTimer()
+			sink = buf
+		})
+	}
+}
+
+func BenchmarkNewFromString(b *testing.B) {
+	rng := rand.New(rand.NewSource(99))
+	for _, length := range textLengths {
+		s := Random(length, rng).String()
+		b.Run(fmt.Sprintf("len=%d/per-bit", length), func(b *testing.B) {
+			b.SetBytes(int64(length))
+			b.ReportAllocs()
+			var bs *Bitstring
+			for i := 0; i < b.N; i++ {
+				bs, _ = newFromStringPerBit(s)
+			}
+			b.StopTimer()
+			sink = bs
+		})
+		b.Run(fmt.Sprintf("len=%d/NewFromString", length), func(b *testing.B) {
+			b.SetBytes(int64(length))
+			b.ReportAllocs()
+			var bs *Bitstring
+			for i := 0; i < b.N; i++ {
+				bs, _ = NewFromString(s)
+			}
+			b.StopTimer()
+			sink = bs
+		})
+	}
+}
@@ -10,6 +10,21 @@ const wordsize = 32 << (^uint(0) >> 63) // 32 or 64
 // bitmask returns a mask where only the nth bit of a word is set.
 func bitmask(n uint64) uint64 { return 1 << n }
 
+// maxLength is the maximum length of a Bitstring built from untrusted input. It
+// leaves room to round lengths up to a multiple of 64 without overflowing an
+// int.
+const maxLength = math.MaxInt - 63
+
+// nwords returns the number of words needed to hold a bit string of the given
+// length. It doesn't overflow, even for a length of math.MaxInt.
+func nwords(length int) int {
+	n := length / 64
+	if length%64 != 0 {
+		n++
+	}
+	return n
+}
+
 // wordoffset returns, for a given bit n of a bit string, the offset
 // of the word that contains bit n.
 func wordoffset(n uint64) uint64 { return n / 64 }
@@ -141,6 +156,50 @@ func rightShiftBits(words []uint64, n uint64) {
 	}
 }
 
+// shl shifts the bits of src by s positions towards the most significant end
+// and stores the result in dst, vacated bits are filled with zeroes. Bits
+// shifted out of the last word are lost. invariant: len(dst) == len(src). dst
+// and src may be the same slice.
+func shl(dst, src []uint64, s uint64) {
+	w, b := int(wordoffset(s)), bitoffset(s)
+	if w > len(dst) {
+		w = len(dst)
+	}
+
+	for i := len(dst) - 1; i >= w; i-- {
+		v := src[i-w] << b
+		if b != 0 && i > w {
+			v |= src[i-w-1] >> (64 - b)
+		}
+		dst[i] = v
+	}
+	for i := w - 1; i >= 0; i-- {
+		dst[i] = 0
+	}
+}
+
+// shr shifts the bits of src by s positions towards the least significant end
+// and stores the result in dst, vacated bits are filled with zeroes.
+// invariant: len(dst) == len(src). dst and src may be the same slice.
+func shr(dst, src []uint64, s uint64) {
+	w, b := int(wordoffset(s)), bitoffset(s)
+	if w > len(dst) {
+		w = len(dst)
+	}
+
+	last := len(dst) - w
+	for i := 0; i < last; i++ {
+		v := src[i+w] >> b
+		if b != 0 && i+w+1 < len(src) {
+			v |= src[i+w+1] << (64 - b)
+		}
+		dst[i] = v
+	}
+	for i := last; i < len(dst); i++ {
+		dst[i] = 0
+	}
+}
+
 // if n is a power of 2, ispow2 returns (v, true) such that (1<<v) gives n, or
 // (0, false) if n is not a power of 2.
 //
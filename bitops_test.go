@@ -8,6 +8,19 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func Test_nwords(t *testing.T) {
+	tests := []struct {
+		length, want int
+	}{
+		{0, 0}, {1, 1}, {63, 1}, {64, 1}, {65, 2}, {128, 2},
+		{math.MaxInt - 63, math.MaxInt / 64},
+		{math.MaxInt, math.MaxInt/64 + 1},
+	}
+	for _, tt := range tests {
+		assert.Equalf(t, tt.want, nwords(tt.length), "nwords(%d)", tt.length)
+	}
+}
+
 func Test_lomask(t *testing.T) {
 	tests := []struct {
 		n    uint64
@@ -1,18 +1,21 @@
-// Package bitstring implements a fixed length bit string type and bit
-// manipulation functions.
+// Package bitstring implements a bit string type and bit manipulation
+// functions.
 
 package bitstring
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math/big"
 	"math/bits"
 	"math/rand"
 	"reflect"
+	"slices"
 	"unsafe"
 )
 
-// Bitstring implements a fixed-length bit string.
+// Bitstring implements a bit string. A Bitstring has a fixed length unless
+// explicitly resized with Resize, Truncate or one of the Append methods.
 //
 // Internally, bits are packed into an array of machine word integers. This
 // implementation makes more efficient use of space than the alternative
@@ -30,7 +33,7 @@ type Bitstring struct {
 func New(length int) *Bitstring {
 	return &Bitstring{
 		length: length,
-		data:   make([]uint64, (length+64-1)/64),
+		data:   make([]uint64, nwords(length)),
 	}
 }
 
@@ -66,19 +69,84 @@ func Random(length int, rng *rand.Rand) *Bitstring {
 func NewFromString(s string) (*Bitstring, error) {
 	bs := New(len(s))
 
-	for i, c := range s {
-		switch c {
-		case '0':
-			continue
-		case '1':
-			bs.SetBit(len(s) - i - 1)
-		default:
-			return nil, fmt.Errorf("illegal character at position %v: %#U", i, c)
+	// Convert 64 digits, a word, at a time, starting from the least
+	// significant ones, that is from the end of s.
+	i := 0
+	for ; 64*(i+1) <= len(s); i++ {
+		d := s[len(s)-64*(i+1) : len(s)-64*i]
+		var w, bad uint64
+		for j := 0; j < 8; j++ {
+			x := loadUint64(d[56-8*j:])
+			bad |= x&0xfefefefefefefefe ^ 0x3030303030303030
+			w |= digitsToByte(x) << (8 * j)
+		}
+		// Each byte must be either '0' (0x30) or '1' (0x31).
+		if bad != 0 {
+			return nil, illegalChar(s)
+		}
+		bs.data[i] = w
+	}
+
+	// Remaining most significant digits, at the start of s, fill the last
+	// word 8 digits at a time, then one by one.
+	if rem := len(s) - 64*i; rem != 0 {
+		var w uint64
+		n := 0
+		for ; n+8 <= rem; n += 8 {
+			x := loadUint64(s[rem-n-8:])
+			if x&0xfefefefefefefefe != 0x3030303030303030 {
+				return nil, illegalChar(s)
+			}
+			w |= digitsToByte(x) << n
 		}
+		for ; n < rem; n++ {
+			switch s[rem-n-1] {
+			case '0':
+			case '1':
+				w |= 1 << n
+			default:
+				return nil, illegalChar(s)
+			}
+		}
+		bs.data[i] = w
 	}
 	return bs, nil
 }
 
+// digitsToByte gathers the low bit of each byte of x, 8 binary digits loaded
+// with loadUint64, into a byte. The first digit, the most significant one, ends
+// up in bit 7.
+func digitsToByte(x uint64) uint64 {
+	return (x & 0x0101010101010101) * 0x8040201008040201 >> 56
+}
+
+// loadUint64 returns the first 8 bytes of s as a little endian uint64.
+func loadUint64(s string) uint64 {
+	_ = s[7] // bounds check hint to compiler
+	return uint64(s[0]) | uint64(s[1])<<8 | uint64(s[2])<<16 | uint64(s[3])<<24 |
+		uint64(s[4])<<32 | uint64(s[5])<<40 | uint64(s[6])<<48 | uint64(s[7])<<56
+}
+
+// illegalChar returns the error reporting the first character of s that is
+// neither 0 nor 1.
+func illegalChar(s string) error {
+	for i, c := range s {
+		if c != '0' && c != '1' {
+			return fmt.Errorf("illegal character at position %v: %#U", i, c)
+		}
+	}
+	return nil
+}
+
+// clearPadding zeroes the out-of-bounds bits of the last word, that is the bits
+// past the bitstring length. OnesCount and ZeroesCount rely on those bits being
+// 0.
+func (bs *Bitstring) clearPadding() {
+	if nused := bitoffset(uint64(bs.length)); nused != 0 {
+		bs.data[len(bs.data)-1] &= lomask(nused)
+	}
+}
+
 // Len returns the length if bs, that is the number of bits it contains.
 func (bs *Bitstring) Len() int {
 	return int(bs.length)
@@ -169,12 +237,11 @@ func (bs *Bitstring) Reverse() {
 	// We first reverse the whole bitstring.
 	alignedRev(bs.data)
 
-	if bs.length%64 == 0 {
-		// No need for extra shifting.
-		return
+	// Unless the length is a multiple of 64, there's extra shifting to do.
+	if bs.length%64 != 0 {
+		rightShiftBits(bs.data, bitoffset(uint64(64-bs.length)))
 	}
-
-	rightShiftBits(bs.data, bitoffset(uint64(64-bs.length)))
+	bs.mustHaveClearPadding()
 }
 
 // NewFromBig creates a new Bitstring using the absolute value of the big.Int
@@ -228,15 +295,45 @@ func (bs *Bitstring) BigInt() *big.Int {
 
 // String returns a string representation of bs in big endian order.
 func (bs *Bitstring) String() string {
-	b := make([]byte, bs.length)
-	for i := 0; i < bs.length; i++ {
-		if bs.Bit(i) {
-			b[bs.length-1-i] = '1'
-		} else {
-			b[bs.length-1-i] = '0'
+	b := bs.appendText(make([]byte, 0, bs.length))
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// appendText appends the string representation of bs to dst and returns the
+// extended slice.
+func (bs *Bitstring) appendText(dst []byte) []byte {
+	n := bs.length
+	start := len(dst)
+	dst = slices.Grow(dst, n)[:start+n]
+	b := dst[start:]
+
+	// Convert 64 bits, a word, at a time, starting from the least significant
+	// ones, that is from the end of b.
+	i := 0
+	for ; 64*(i+1) <= n; i++ {
+		d := b[n-64*(i+1) : n-64*i]
+		w := bs.data[i]
+		for j := 0; j < 8; j++ {
+			binary.LittleEndian.PutUint64(d[56-8*j:], digitsLut[byte(w)])
+			w >>= 8
+		}
+	}
+
+	// Remaining most significant bits, at the start of b, from the last word
+	// 8 bits at a time, then one by one.
+	if rem := n - 64*i; rem != 0 {
+		w := bs.data[i]
+		k := 0
+		for ; k+8 <= rem; k += 8 {
+			binary.LittleEndian.PutUint64(b[rem-k-8:], digitsLut[byte(w)])
+			w >>= 8
+		}
+		for ; k < rem; k++ {
+			b[rem-k-1] = '0' + byte(w&1)
+			w >>= 1
 		}
 	}
-	return string(b)
+	return dst
 }
 
 // Clone creates and returns a new Bitstring that is a clone of src.
@@ -263,6 +360,7 @@ func Copy(dst, src *Bitstring) {
 	}
 
 	copy(dst.data, src.data)
+	dst.mustHaveClearPadding()
 }
 
 // Equals returns true if bs and other have the same length and each bit are
@@ -340,14 +438,176 @@ func (bs *Bitstring) TrailingZeroes() int {
 	return n
 }
 
-/*
-// RotateLeft rotates the bitstring by (k mod len) bits.
+// LeadingOnes returns the number of leading 1 bits in bs. (i.e the number of
+// ones in the leftmost side of the string representation).
+func (bs *Bitstring) LeadingOnes() int {
+	bitoff := int(bitoffset(uint64(bs.length)))
+	start := len(bs.data) - 1
+
+	n := 0
+	for i := start; i >= 0; i-- {
+		// We treat the first word separately if the bistring length is not a
+		// multiple of the wordsize, we first align its useful bits on the left
+		// so that the 0s shifted in stop the count.
+		if i == start && bitoff != 0 {
+			leading := bits.LeadingZeros64(^(bs.data[i] << uint(64-bitoff)))
+			n += leading
+			if leading != bitoff {
+				break // early exit if useful bits are not all 1s.
+			}
+		} else {
+			// Subsequent words
+			leading := bits.LeadingZeros64(^bs.data[i])
+			n += leading
+			if leading != 64 {
+				break
+			}
+		}
+	}
+
+	return n
+}
+
+// TrailingOnes returns the number of trailing 1 bits in bs. (i.e the number of
+// ones in the rightmost side of the string representation).
+func (bs *Bitstring) TrailingOnes() int {
+	n := 0
+	for i := 0; i < len(bs.data); i++ {
+		// Since the extra bits of the last word are always 0s, there's no need
+		// to treat it separately, the count stops there.
+		trailing := bits.TrailingZeros64(^bs.data[i])
+
+		n += trailing
+		if trailing != 64 {
+			break
+		}
+	}
+
+	return n
+}
+
+// RotateLeft rotates bs by (k mod bs.Len()) bits towards the most significant
+// end, that is towards the left side of the string representation. To rotate
+// towards the least significant end, call RotateLeft(-k).
 func (bs *Bitstring) RotateLeft(k int) {
-	panic("unimplemented")
+	if bs.length == 0 {
+		return
+	}
+	k %= bs.length
+	if k < 0 {
+		k += bs.length
+	}
+	if k == 0 {
+		return
+	}
+
+	// First rotate the whole words, padding included, by k bits: rotate the
+	// words by k/64, then the bits by k%64, carrying the bits shifted out of
+	// the last word.
+	words := bs.data
+	q, r := k/64, uint64(k%64)
+	slices.Reverse(words)
+	slices.Reverse(words[:q])
+	slices.Reverse(words[q:])
+	if r != 0 {
+		carry := words[len(words)-1] >> (64 - r)
+		shl(words, words, r)
+		words[0] |= carry
+	}
+
+	// The padding bits, if any, now sit in the [k-pad, k) range, and the pad
+	// low bits of the original top k bits are above the bitstring length.
+	// Remove the former and move the latter at the bottom.
+	if pad := 64*len(words) - bs.length; pad != 0 {
+		m := min(k, pad)
+		low := words[len(words)-1] >> (64 - pad)
+		if k > pad {
+			lw := words[:nwords(k)]
+			last := lw[len(lw)-1]
+			shl(lw, lw, uint64(pad))
+			if end := bitoffset(uint64(k)); end != 0 {
+				lw[len(lw)-1] = transferbits(lw[len(lw)-1], last, himask(end))
+			}
+		}
+		words[0] = transferbits(words[0], low, lomask(uint64(m)))
+		bs.clearPadding()
+	}
+	bs.mustHaveClearPadding()
 }
 
-// RotateRight rotates the bitstring by (k mod len) bits.
+// RotateRight rotates bs by (k mod bs.Len()) bits towards the least significant
+// end, that is towards the right side of the string representation. To rotate
+// towards the most significant end, call RotateRight(-k).
 func (bs *Bitstring) RotateRight(k int) {
-	panic("unimplemented")
+	if bs.length == 0 {
+		return
+	}
+	bs.RotateLeft(-(k % bs.length))
+}
+
+// ShiftLeft shifts bs by n bits towards the most significant end, that is
+// towards the left side of the string representation. The n least significant
+// bits are set to 0 and the n most significant bits are lost. If n is greater
+// than or equal to bs.Len(), all bits are cleared. Panics if n is negative.
+func (bs *Bitstring) ShiftLeft(n int) {
+	mustShiftCount(n)
+	shl(bs.data, bs.data, uint64(n))
+	bs.clearPadding()
+	bs.mustHaveClearPadding()
+}
+
+// ShiftLeftOnes is like ShiftLeft except that the n least significant bits are
+// set to 1.
+func (bs *Bitstring) ShiftLeftOnes(n int) {
+	bs.ShiftLeft(n)
+	if n > bs.length {
+		n = bs.length
+	}
+	if n != 0 {
+		bs.SetRange(0, n)
+	}
+	bs.mustHaveClearPadding()
+}
+
+// ShiftRight shifts bs by n bits towards the least significant end, that is
+// towards the right side of the string representation. The n most significant
+// bits are set to 0 and the n least significant bits are lost. If n is greater
+// than or equal to bs.Len(), all bits are cleared. Panics if n is negative.
+func (bs *Bitstring) ShiftRight(n int) {
+	mustShiftCount(n)
+	shr(bs.data, bs.data, uint64(n))
+	bs.mustHaveClearPadding()
+}
+
+// ShiftRightOnes is like ShiftRight except that the n most significant bits
+// are set to 1.
+func (bs *Bitstring) ShiftRightOnes(n int) {
+	bs.ShiftRight(n)
+	if n > bs.length {
+		n = bs.length
+	}
+	if n != 0 {
+		bs.SetRange(bs.length-n, n)
+	}
+	bs.mustHaveClearPadding()
+}
+
+// ShiftRightArith performs an arithmetic right shift of bs by n bits, that is
+// bs is considered as a two's complement signed integer and its most
+// significant bit (the sign bit) is copied into the n most significant bits.
+// Panics if n is negative.
+func (bs *Bitstring) ShiftRightArith(n int) {
+	if bs.length != 0 && bs.Bit(bs.length-1) {
+		bs.ShiftRightOnes(n)
+	} else {
+		bs.ShiftRight(n)
+	}
+	bs.mustHaveClearPadding()
+}
+
+// mustShiftCount panics if n is not a valid shift count.
+func mustShiftCount(n int) {
+	if n < 0 {
+		panic("Bitstring: negative shift count")
+	}
 }
-*/
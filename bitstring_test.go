@@ -92,6 +92,40 @@ func TestString(t *testing.T) {
 	}
 }
 
+func TestStringLong(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	for _, length := range []int{1, 7, 8, 9, 63, 64, 65, 72, 79, 127, 128, 129, 143, 1029} {
+		bs := Random(length, rng)
+
+		// Compare with the bit-by-bit representation.
+		var sb strings.Builder
+		for i := length - 1; i >= 0; i-- {
+			if bs.Bit(i) {
+				sb.WriteByte('1')
+			} else {
+				sb.WriteByte('0')
+			}
+		}
+		str := sb.String()
+		assert.Equal(t, str, bs.String())
+
+		got, err := NewFromString(str)
+		assert.NoError(t, err)
+		equalbits(t, got, bs)
+
+		// The first illegal character is reported, wherever it is.
+		for _, pos := range []int{0, length / 2, length - 1} {
+			b := []byte(str)
+			b[pos] = '2'
+			b[length-1] = 'x'
+			_, err := NewFromString(string(b))
+			c := b[pos]
+			assert.EqualError(t, err, fmt.Sprintf("illegal character at position %d: %#U", pos, rune(c)))
+		}
+	}
+}
+
 func TestReverse(t *testing.T) {
 	tests := []string{
 		"0000000000000000000000000000000000000000000000000000000000000001",
@@ -423,3 +457,146 @@ func TestLeadingTrailingZeroes(t *testing.T) {
 		})
 	}
 }
+
+func TestRotate(t *testing.T) {
+	// rotl rotates the string representation of a bitstring by k characters
+	// to the left.
+	rotl := func(s string, k int) string {
+		k %= len(s)
+		if k < 0 {
+			k += len(s)
+		}
+		return s[k:] + s[:k]
+	}
+
+	rng := rand.New(rand.NewSource(99))
+	for _, length := range []int{1, 2, 7, 63, 64, 65, 127, 128, 129, 300, 1029} {
+		for _, k := range []int{0, 1, 3, 31, 63, 64, 65, 129, -1, -65, length - 1, length, length + 1, 3*length + 7} {
+			t.Run(fmt.Sprintf("len=%d,k=%d", length, k), func(t *testing.T) {
+				bs := Random(length, rng)
+				s := bs.String()
+
+				rot := bs.Clone()
+				rot.RotateLeft(k)
+				want, _ := NewFromString(rotl(s, k))
+				equalbits(t, rot, want)
+
+				rot = bs.Clone()
+				rot.RotateRight(k)
+				want, _ = NewFromString(rotl(s, -k))
+				equalbits(t, rot, want)
+			})
+		}
+	}
+
+	// Rotating an empty bitstring is a no-op.
+	New(0).RotateLeft(3)
+	New(0).RotateRight(3)
+}
+
+func TestShift(t *testing.T) {
+	// shl and shr shift the string representation of a bitstring by n
+	// characters, filling vacated characters with fill.
+	shl := func(s string, n int, fill string) string {
+		if n > len(s) {
+			n = len(s)
+		}
+		return s[n:] + strings.Repeat(fill, n)
+	}
+	shr := func(s string, n int, fill string) string {
+		if n > len(s) {
+			n = len(s)
+		}
+		return strings.Repeat(fill, n) + s[:len(s)-n]
+	}
+
+	rng := rand.New(rand.NewSource(99))
+	for _, length := range []int{1, 2, 7, 63, 64, 65, 127, 128, 129, 300} {
+		for _, n := range []int{0, 1, 3, 31, 63, 64, 65, 129, length - 1, length, length + 1, 2*length + 5} {
+			t.Run(fmt.Sprintf("len=%d,n=%d", length, n), func(t *testing.T) {
+				bs := Random(length, rng)
+				s := bs.String()
+				sign := s[:1]
+
+				tests := []struct {
+					name  string
+					shift func(*Bitstring, int)
+					want  string
+				}{
+					{"ShiftLeft", (*Bitstring).ShiftLeft, shl(s, n, "0")},
+					{"ShiftLeftOnes", (*Bitstring).ShiftLeftOnes, shl(s, n, "1")},
+					{"ShiftRight", (*Bitstring).ShiftRight, shr(s, n, "0")},
+					{"ShiftRightOnes", (*Bitstring).ShiftRightOnes, shr(s, n, "1")},
+					{"ShiftRightArith", (*Bitstring).ShiftRightArith, shr(s, n, sign)},
+				}
+				for _, tt := range tests {
+					got := bs.Clone()
+					tt.shift(got, n)
+					want, _ := NewFromString(tt.want)
+					equalbits(t, got, want)
+				}
+			})
+		}
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		bs := New(0)
+		bs.ShiftLeft(1)
+		bs.ShiftLeftOnes(1)
+		bs.ShiftRight(1)
+		bs.ShiftRightOnes(1)
+		bs.ShiftRightArith(1)
+		assert.Equal(t, 0, bs.Len())
+	})
+
+	t.Run("negative shift count", func(t *testing.T) {
+		assert.Panics(t, func() { New(10).ShiftLeft(-1) })
+		assert.Panics(t, func() { New(10).ShiftRight(-1) })
+	})
+}
+
+func TestLeadingTrailingOnes(t *testing.T) {
+	tests := []string{
+		"0",
+		"1",
+		"10",
+		"01",
+		"11",
+		strings.Repeat("1", 63),
+		strings.Repeat("1", 64),
+		strings.Repeat("1", 65),
+		"0" + strings.Repeat("1", 64),
+		strings.Repeat("1", 64) + "0",
+		"1" + strings.Repeat("0", 63) + "1",
+		strings.Repeat("1", 70) + strings.Repeat("0", 60) + strings.Repeat("1", 129),
+		strings.Repeat("1", 129) + strings.Repeat("0", 60) + strings.Repeat("1", 70),
+	}
+
+	for _, s := range tests {
+		t.Run("", func(t *testing.T) {
+			bs, _ := NewFromString(s)
+
+			leading := len(s) - len(strings.TrimLeft(s, "1"))
+			trailing := len(s) - len(strings.TrimRight(s, "1"))
+
+			if got := bs.LeadingOnes(); got != leading {
+				t.Errorf("%q leading ones = %d, want %d", s, got, leading)
+			}
+			if got := bs.TrailingOnes(); got != trailing {
+				t.Errorf("%q trailing ones = %d, want %d", s, got, trailing)
+			}
+
+			// Complementing bs swaps ones and zeroes.
+			bs.Not(bs)
+			if got := bs.LeadingZeroes(); got != leading {
+				t.Errorf("^%q leading zeroes = %d, want %d", s, got, leading)
+			}
+			if got := bs.TrailingZeroes(); got != trailing {
+				t.Errorf("^%q trailing zeroes = %d, want %d", s, got, trailing)
+			}
+		})
+	}
+
+	assert.Zero(t, New(0).LeadingOnes())
+	assert.Zero(t, New(0).TrailingOnes())
+}
@@ -0,0 +1,132 @@
+package bitstring
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// BitOrder specifies how the bits of a Bitstring are laid out in a slice of
+// bytes.
+type BitOrder int
+
+const (
+	// MSBFirst, or network order, lays out bits from the most significant to
+	// the least significant, that is in the order of the string
+	// representation. The most significant bit of the Bitstring is the most
+	// significant bit of the first byte. If the Bitstring length is not a
+	// multiple of 8, the last byte holds the least significant bits of the
+	// Bitstring in its high-order bits, its low-order bits are 0.
+	MSBFirst BitOrder = iota
+
+	// LSBFirst lays out bits from the least significant to the most
+	// significant. The least significant bit of the Bitstring (bit 0) is the
+	// least significant bit of the first byte. If the Bitstring length is not a
+	// multiple of 8, the last byte holds the most significant bits of the
+	// Bitstring in its low-order bits, its high-order bits are 0.
+	LSBFirst
+)
+
+// String returns the name of the bit order.
+func (o BitOrder) String() string {
+	switch o {
+	case MSBFirst:
+		return "MSBFirst"
+	case LSBFirst:
+		return "LSBFirst"
+	}
+	return fmt.Sprintf("BitOrder(%d)", int(o))
+}
+
+// NewFromBytes returns a Bitstring of nbits bits, read from b in the given bit
+// order. b must contain at least nbits bits, extra bytes and extra bits of the
+// last byte are ignored.
+func NewFromBytes(b []byte, nbits int, order BitOrder) (*Bitstring, error) {
+	if nbits < 0 {
+		return nil, fmt.Errorf("negative number of bits: %d", nbits)
+	}
+	nbytes := (nbits + 8 - 1) / 8
+	if len(b) < nbytes {
+		return nil, fmt.Errorf("not enough bytes for %d bits: got %d, want %d", nbits, len(b), nbytes)
+	}
+	b = b[:nbytes]
+
+	bs := New(nbits)
+	switch order {
+	case MSBFirst:
+		// Read the bits from the most significant to the least significant,
+		// 64 bits at a time, then a byte at a time.
+		off := nbits
+		for ; len(b) >= 8 && off >= 64; b = b[8:] {
+			off -= 64
+			bs.SetUint64(off, binary.BigEndian.Uint64(b))
+		}
+		for ; off >= 8; b = b[1:] {
+			off -= 8
+			bs.SetUint8(off, b[0])
+		}
+		if off != 0 {
+			// The remaining bits are the high-order bits of the last byte.
+			bs.SetUintn(0, off, uint64(b[0]>>(8-off)))
+		}
+	case LSBFirst:
+		i := 0
+		for ; len(b) >= 8; b = b[8:] {
+			bs.data[i] = binary.LittleEndian.Uint64(b)
+			i++
+		}
+		if len(b) != 0 {
+			var w uint64
+			for j := range b {
+				w |= uint64(b[j]) << (8 * j)
+			}
+			bs.data[i] = w
+		}
+		bs.clearPadding()
+	default:
+		return nil, fmt.Errorf("invalid bit order: %v", order)
+	}
+	return bs, nil
+}
+
+// Bytes returns the bits of bs laid out in a new slice of bytes, in the given
+// bit order. The returned slice has (bs.Len()+7)/8 bytes.
+func (bs *Bitstring) Bytes(order BitOrder) []byte {
+	return bs.AppendBytes(make([]byte, 0, (bs.length+8-1)/8), order)
+}
+
+// AppendBytes appends the bits of bs, laid out in the given bit order, to dst
+// and returns the extended slice. (bs.Len()+7)/8 bytes are appended. Panics if
+// order is not a valid BitOrder.
+func (bs *Bitstring) AppendBytes(dst []byte, order BitOrder) []byte {
+	switch order {
+	case MSBFirst:
+		// Write the bits from the most significant to the least significant,
+		// 64 bits at a time, then a byte at a time.
+		off := bs.length
+		for ; off >= 64; off -= 64 {
+			dst = binary.BigEndian.AppendUint64(dst, bs.Uint64(off-64))
+		}
+		for ; off >= 8; off -= 8 {
+			dst = append(dst, bs.Uint8(off-8))
+		}
+		if off != 0 {
+			// The remaining bits go into the high-order bits of the last byte.
+			dst = append(dst, byte(bs.Uintn(0, off))<<(8-off))
+		}
+	case LSBFirst:
+		nbytes := (bs.length + 8 - 1) / 8
+		i := 0
+		for ; nbytes >= 8; nbytes -= 8 {
+			dst = binary.LittleEndian.AppendUint64(dst, bs.data[i])
+			i++
+		}
+		// Extra bits of the last word are 0s, so are the high-order bits of
+		// the last byte.
+		for j := 0; j < nbytes; j++ {
+			dst = append(dst, byte(bs.data[i]>>(8*j)))
+		}
+	default:
+		panic(fmt.Sprintf("invalid bit order: %v", order))
+	}
+	return dst
+}
@@ -0,0 +1,129 @@
+package bitstring
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		str      string
+		msbFirst []byte
+		lsbFirst []byte
+	}{
+		{
+			str:      "",
+			msbFirst: []byte{},
+			lsbFirst: []byte{},
+		},
+		{
+			str:      "1",
+			msbFirst: []byte{0x80},
+			lsbFirst: []byte{0x01},
+		},
+		{
+			str:      "10100101",
+			msbFirst: []byte{0xa5},
+			lsbFirst: []byte{0xa5},
+		},
+		{
+			str:      "101010111100", // 0xabc
+			msbFirst: []byte{0xab, 0xc0},
+			lsbFirst: []byte{0xbc, 0x0a},
+		},
+		{
+			str:      "0001001000110100010101100111100010011010101111001101111011110000", // 0x123456789abcdef0
+			msbFirst: []byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0},
+			lsbFirst: []byte{0xf0, 0xde, 0xbc, 0x9a, 0x78, 0x56, 0x34, 0x12},
+		},
+		{
+			str:      "10001001000110100010101100111100010011010101111001101111011110000", // 0x1123456789abcdef0
+			msbFirst: []byte{0x89, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e, 0x6f, 0x78, 0x00},
+			lsbFirst: []byte{0xf0, 0xde, 0xbc, 0x9a, 0x78, 0x56, 0x34, 0x12, 0x01},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			bs, _ := NewFromString(tt.str)
+
+			assert.Equal(t, tt.msbFirst, bs.Bytes(MSBFirst))
+			assert.Equal(t, tt.lsbFirst, bs.Bytes(LSBFirst))
+
+			got, err := NewFromBytes(tt.msbFirst, len(tt.str), MSBFirst)
+			assert.NoError(t, err)
+			equalbits(t, got, bs)
+
+			got, err = NewFromBytes(tt.lsbFirst, len(tt.str), LSBFirst)
+			assert.NoError(t, err)
+			equalbits(t, got, bs)
+		})
+	}
+}
+
+func TestBytesRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	for _, length := range []int{1, 7, 8, 9, 63, 64, 65, 71, 72, 100, 128, 300} {
+		t.Run(fmt.Sprintf("len=%d", length), func(t *testing.T) {
+			bs := Random(length, rng)
+
+			for _, order := range []BitOrder{MSBFirst, LSBFirst} {
+				b := bs.Bytes(order)
+				assert.Len(t, b, (length+7)/8)
+
+				got, err := NewFromBytes(b, length, order)
+				assert.NoError(t, err)
+				equalbits(t, got, bs)
+
+				// Extra bits in the last byte, and extra bytes, are ignored.
+				b = append(b, 0xff)
+				if length%8 != 0 {
+					if order == MSBFirst {
+						b[len(b)-2] |= 0xff >> (length % 8)
+					} else {
+						b[len(b)-2] |= 0xff << (length % 8)
+					}
+				}
+				got, err = NewFromBytes(b, length, order)
+				assert.NoError(t, err)
+				equalbits(t, got, bs)
+
+				// AppendBytes appends.
+				prefix := []byte{1, 2, 3}
+				assert.Equal(t, append(prefix, bs.Bytes(order)...), bs.AppendBytes([]byte{1, 2, 3}, order))
+			}
+
+			// MSBFirst is the order of the string representation.
+			var sb strings.Builder
+			for _, c := range bs.Bytes(MSBFirst) {
+				fmt.Fprintf(&sb, "%08b", c)
+			}
+			assert.Equal(t, bs.String(), sb.String()[:length])
+
+			// LSBFirst is the order of little-endian big.Int bytes.
+			if length%8 == 0 {
+				want := bs.BigInt().FillBytes(make([]byte, length/8))
+				assert.Equal(t, want, reverseBytes(bs.Bytes(LSBFirst)))
+				assert.Equal(t, want, bs.Bytes(MSBFirst))
+			}
+		})
+	}
+}
+
+func TestNewFromBytesErrors(t *testing.T) {
+	_, err := NewFromBytes([]byte{1, 2}, 17, MSBFirst)
+	assert.Error(t, err)
+
+	_, err = NewFromBytes([]byte{1, 2}, -1, LSBFirst)
+	assert.Error(t, err)
+
+	_, err = NewFromBytes([]byte{1, 2}, 16, BitOrder(7))
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { New(8).Bytes(BitOrder(7)) })
+}
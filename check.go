@@ -0,0 +1,133 @@
+package bitstring
+
+import "fmt"
+
+// The functions in this file validate the arguments of the Bitstring methods.
+// They're used by the Checked API, and by the debug build.
+
+// checkIndex returns an error if i is not the index of a bit of bs.
+func (bs *Bitstring) checkIndex(i int) error { return checkIndex(i, bs.length) }
+
+// checkRange returns an error if the range of bits [off, off+n) doesn't exist
+// in bs. Empty ranges are valid, as long as off is in [0, bs.Len()].
+func (bs *Bitstring) checkRange(off, n int) error { return checkRange(off, n, bs.length) }
+
+// checkIndex returns an error if i is not in [0, length).
+func checkIndex(i, length int) error {
+	switch {
+	case i < 0:
+		return fmt.Errorf("%w: bit %d", ErrNegativeOffset, i)
+	case i >= length:
+		return fmt.Errorf("%w: bit %d, length %d", ErrOutOfRange, i, length)
+	}
+	return nil
+}
+
+// checkRange returns an error if the range [off, off+n) is not included in
+// [0, length).
+func checkRange(off, n, length int) error {
+	switch {
+	case off < 0:
+		return fmt.Errorf("%w: offset %d", ErrNegativeOffset, off)
+	case n < 0:
+		return fmt.Errorf("%w: negative length %d", ErrWidth, n)
+	case off > length || n > length-off:
+		return fmt.Errorf("%w: range [%d, %d), length %d", ErrOutOfRange, off, off+n, length)
+	}
+	return nil
+}
+
+// checkField returns an error if the n-bit field at offset off doesn't exist
+// in bs, or if n is not in [1, max].
+func (bs *Bitstring) checkField(off, n, max int) error {
+	if n < 1 || n > max {
+		return fmt.Errorf("%w: %d bits, must be in [1, %d]", ErrWidth, n, max)
+	}
+	return bs.checkRange(off, n)
+}
+
+// checkIndex returns an error if i is not the index of a bit of v, or if the
+// range of v doesn't exist anymore in its parent.
+func (v View) checkIndex(i int) error {
+	if err := v.checkParent(); err != nil {
+		return err
+	}
+	return checkIndex(i, v.length)
+}
+
+// checkRange returns an error if the range of bits [off, off+n) doesn't exist
+// in v, or if the range of v doesn't exist anymore in its parent.
+func (v View) checkRange(off, n int) error {
+	if err := v.checkParent(); err != nil {
+		return err
+	}
+	return checkRange(off, n, v.length)
+}
+
+// checkField returns an error if the n-bit field at offset off doesn't exist
+// in v, or if n is not in [1, max].
+func (v View) checkField(off, n, max int) error {
+	if n < 1 || n > max {
+		return fmt.Errorf("%w: %d bits, must be in [1, %d]", ErrWidth, n, max)
+	}
+	return v.checkRange(off, n)
+}
+
+// checkParent returns an error if the range of v doesn't exist anymore in its
+// parent, that is if the parent has shrunk.
+func (v View) checkParent() error {
+	if err := v.bs.checkRange(v.off, v.length); err != nil {
+		return fmt.Errorf("parent has shrunk: %w", err)
+	}
+	return nil
+}
+
+// checkCount returns an error wrapping ErrWidth if the count of bits n is
+// negative.
+func checkCount(n int) error {
+	if n < 0 {
+		return fmt.Errorf("%w: negative count %d", ErrWidth, n)
+	}
+	return nil
+}
+
+// checkLength returns an error if a Bitstring can't have the given length,
+// that is if length is negative or greater than maxLength.
+func checkLength(length int) error {
+	if err := checkCount(length); err != nil {
+		return err
+	}
+	if length > maxLength {
+		return fmt.Errorf("%w: length %d exceeds %d", ErrOutOfRange, length, maxLength)
+	}
+	return nil
+}
+
+// checkGrow returns an error if bs can't grow by n bits, that is if n is
+// negative or if bs.Len()+n would be greater than maxLength.
+func (bs *Bitstring) checkGrow(n int) error {
+	if err := checkCount(n); err != nil {
+		return err
+	}
+	if n > maxLength-bs.length {
+		return fmt.Errorf("%w: length %d+%d exceeds %d", ErrOutOfRange, bs.length, n, maxLength)
+	}
+	return nil
+}
+
+// checkUintn returns an error wrapping ErrOverflow if val can't be represented
+// as an n-bit unsigned integer.
+func checkUintn(n int, val uint64) error {
+	if n < 64 && val>>n != 0 {
+		return fmt.Errorf("%w: %d doesn't fit in %d bits", ErrOverflow, val, n)
+	}
+	return nil
+}
+
+// checkSameLength returns an error if x and y don't have the same length.
+func checkSameLength(x, y *Bitstring) error {
+	if x.length != y.length {
+		return fmt.Errorf("%w: %d != %d", ErrLengthMismatch, x.length, y.length)
+	}
+	return nil
+}
@@ -0,0 +1,839 @@
+package bitstring
+
+import "fmt"
+
+// Checked is a wrapper around a Bitstring whose methods validate their
+// arguments and return an error instead of having undefined behavior. It's
+// meant for untrusted input, such as offsets and lengths read from a packet,
+// at the cost of a few comparisons per call. The methods of Bitstring are not
+// affected and remain unchecked.
+//
+// Errors wrap one of ErrOutOfRange, ErrNegativeOffset, ErrWidth,
+// ErrLengthMismatch or ErrOverflow, use errors.Is to test them. When a method
+// returns an error, the Bitstring is left untouched.
+//
+// Methods that can't fail on any argument, such as AppendBit, OnesCount or
+// RotateLeft, have no Checked counterpart: call them on Unchecked(). Views
+// returned by Slice are checked too, see CheckedView, and the generic
+// accessors have the GetChecked, GetNChecked, SetChecked and SetNChecked
+// counterparts.
+type Checked struct {
+	bs *Bitstring
+}
+
+// Checked returns a Checked wrapper around bs.
+func (bs *Bitstring) Checked() Checked { return Checked{bs: bs} }
+
+// Unchecked returns the underlying Bitstring.
+func (c Checked) Unchecked() *Bitstring { return c.bs }
+
+// Len returns the length of the underlying Bitstring.
+func (c Checked) Len() int { return c.bs.length }
+
+/* single bit */
+
+// Bit returns a boolean indicating whether the bit at index i is set.
+func (c Checked) Bit(i int) (bool, error) {
+	if err := c.bs.checkIndex(i); err != nil {
+		return false, err
+	}
+	return c.bs.Bit(i), nil
+}
+
+// SetBit sets the bit at index i.
+func (c Checked) SetBit(i int) error {
+	if err := c.bs.checkIndex(i); err != nil {
+		return err
+	}
+	c.bs.SetBit(i)
+	return nil
+}
+
+// ClearBit clears the bit at index i.
+func (c Checked) ClearBit(i int) error {
+	if err := c.bs.checkIndex(i); err != nil {
+		return err
+	}
+	c.bs.ClearBit(i)
+	return nil
+}
+
+// FlipBit flips the bit at index i.
+func (c Checked) FlipBit(i int) error {
+	if err := c.bs.checkIndex(i); err != nil {
+		return err
+	}
+	c.bs.FlipBit(i)
+	return nil
+}
+
+/* unsigned integer get */
+
+// Uint8 interprets the 8 bits at offset off as an uint8 in big endian and
+// returns its value.
+func (c Checked) Uint8(off int) (uint8, error) {
+	if err := c.bs.checkRange(off, 8); err != nil {
+		return 0, err
+	}
+	return c.bs.Uint8(off), nil
+}
+
+// Uint16 interprets the 16 bits at offset off as an uint16 in big endian and
+// returns its value.
+func (c Checked) Uint16(off int) (uint16, error) {
+	if err := c.bs.checkRange(off, 16); err != nil {
+		return 0, err
+	}
+	return c.bs.Uint16(off), nil
+}
+
+// Uint32 interprets the 32 bits at offset off as an uint32 in big endian and
+// returns its value.
+func (c Checked) Uint32(off int) (uint32, error) {
+	if err := c.bs.checkRange(off, 32); err != nil {
+		return 0, err
+	}
+	return c.bs.Uint32(off), nil
+}
+
+// Uint64 interprets the 64 bits at offset off as an uint64 in big endian and
+// returns its value.
+func (c Checked) Uint64(off int) (uint64, error) {
+	if err := c.bs.checkRange(off, 64); err != nil {
+		return 0, err
+	}
+	return c.bs.Uint64(off), nil
+}
+
+// Uintn interprets the n bits at offset off as an n-bit unsigned integer in
+// big endian and returns its value. n must be in [1, 64].
+func (c Checked) Uintn(off, n int) (uint64, error) {
+	if err := c.bs.checkField(off, n, 64); err != nil {
+		return 0, err
+	}
+	return c.bs.Uintn(off, n), nil
+}
+
+/* unsigned integer set */
+
+// SetUint8 sets the 8 bits at offset off with the given int8 value, in big
+// endian.
+func (c Checked) SetUint8(off int, val uint8) error {
+	if err := c.bs.checkRange(off, 8); err != nil {
+		return err
+	}
+	c.bs.SetUint8(off, val)
+	return nil
+}
+
+// SetUint16 sets the 16 bits at offset off with the given int16 value, in big
+// endian.
+func (c Checked) SetUint16(off int, val uint16) error {
+	if err := c.bs.checkRange(off, 16); err != nil {
+		return err
+	}
+	c.bs.SetUint16(off, val)
+	return nil
+}
+
+// SetUint32 sets the 32 bits at offset off with the given int32 value, in big
+// endian.
+func (c Checked) SetUint32(off int, val uint32) error {
+	if err := c.bs.checkRange(off, 32); err != nil {
+		return err
+	}
+	c.bs.SetUint32(off, val)
+	return nil
+}
+
+// SetUint64 sets the 64 bits at offset off with the given int64 value, in big
+// endian.
+func (c Checked) SetUint64(off int, val uint64) error {
+	if err := c.bs.checkRange(off, 64); err != nil {
+		return err
+	}
+	c.bs.SetUint64(off, val)
+	return nil
+}
+
+// SetUintn sets the n bits at offset off with the given n-bit unsigned
+// integer, in big endian. n must be in [1, 64] and val must fit in n bits.
+func (c Checked) SetUintn(off, n int, val uint64) error {
+	if err := c.bs.checkField(off, n, 64); err != nil {
+		return err
+	}
+	if err := checkUintn(n, val); err != nil {
+		return err
+	}
+	c.bs.SetUintn(off, n, val)
+	return nil
+}
+
+/* signed integer get */
+
+// Int8 interprets the 8 bits at offset off as an int8 in big endian and
+// returns its value.
+func (c Checked) Int8(off int) (int8, error) {
+	v, err := c.Uint8(off)
+	return int8(v), err
+}
+
+// Int16 interprets the 16 bits at offset off as an int16 in big endian and
+// returns its value.
+func (c Checked) Int16(off int) (int16, error) {
+	v, err := c.Uint16(off)
+	return int16(v), err
+}
+
+// Int32 interprets the 32 bits at offset off as an int32 in big endian and
+// returns its value.
+func (c Checked) Int32(off int) (int32, error) {
+	v, err := c.Uint32(off)
+	return int32(v), err
+}
+
+// Int64 interprets the 64 bits at offset off as an int64 in big endian and
+// returns its value.
+func (c Checked) Int64(off int) (int64, error) {
+	v, err := c.Uint64(off)
+	return int64(v), err
+}
+
+// Intn interprets the n bits at offset off as an n-bit signed integer in big
+// endian and returns its value, sign-extended. n must be in [1, 64].
+func (c Checked) Intn(off, n int) (int64, error) {
+	if err := c.bs.checkField(off, n, 64); err != nil {
+		return 0, err
+	}
+	return c.bs.Intn(off, n), nil
+}
+
+/* signed integer set */
+
+// SetInt8 sets the 8 bits at offset off with the given int8 value, in big
+// endian.
+func (c Checked) SetInt8(off int, val int8) error { return c.SetUint8(off, uint8(val)) }
+
+// SetInt16 sets the 16 bits at offset off with the given int16 value, in big
+// endian.
+func (c Checked) SetInt16(off int, val int16) error { return c.SetUint16(off, uint16(val)) }
+
+// SetInt32 sets the 32 bits at offset off with the given int32 value, in big
+// endian.
+func (c Checked) SetInt32(off int, val int32) error { return c.SetUint32(off, uint32(val)) }
+
+// SetInt64 sets the 64 bits at offset off with the given int64 value, in big
+// endian.
+func (c Checked) SetInt64(off int, val int64) error { return c.SetUint64(off, uint64(val)) }
+
+// SetIntn sets the n bits at offset off with the given n-bit signed integer in
+// big endian. n must be in [1, 64] and val must fit in n bits.
+func (c Checked) SetIntn(off, n int, val int64) error {
+	if err := c.bs.checkField(off, n, 64); err != nil {
+		return err
+	}
+	return c.bs.SetIntnChecked(off, n, val)
+}
+
+/* gray code */
+
+// Gray8 interprets the 8 bits at offset off as a gray-coded uint8 in big
+// endian and returns its value.
+func (c Checked) Gray8(off int) (uint8, error) {
+	if err := c.bs.checkRange(off, 8); err != nil {
+		return 0, err
+	}
+	return c.bs.Gray8(off), nil
+}
+
+// Gray16 interprets the 16 bits at offset off as a gray-coded uint16 in big
+// endian and returns its value.
+func (c Checked) Gray16(off int) (uint16, error) {
+	if err := c.bs.checkRange(off, 16); err != nil {
+		return 0, err
+	}
+	return c.bs.Gray16(off), nil
+}
+
+// Gray32 interprets the 32 bits at offset off as a gray-coded uint32 in big
+// endian and returns its value.
+func (c Checked) Gray32(off int) (uint32, error) {
+	if err := c.bs.checkRange(off, 32); err != nil {
+		return 0, err
+	}
+	return c.bs.Gray32(off), nil
+}
+
+// Gray64 interprets the 64 bits at offset off as a gray-coded uint64 in big
+// endian and returns its value.
+func (c Checked) Gray64(off int) (uint64, error) {
+	if err := c.bs.checkRange(off, 64); err != nil {
+		return 0, err
+	}
+	return c.bs.Gray64(off), nil
+}
+
+// Grayn interprets the n bits at offset off as an n-bit gray-coded unsigned
+// integer in big endian and returns its value. n must be in [1, 64].
+func (c Checked) Grayn(off, n int) (uint64, error) {
+	if err := c.bs.checkField(off, n, 64); err != nil {
+		return 0, err
+	}
+	return c.bs.Grayn(off, n), nil
+}
+
+// SetGray8 sets the 8 bits at offset off with the gray code of val, in big
+// endian.
+func (c Checked) SetGray8(off int, val uint8) error { return c.SetUint8(off, val^val>>1) }
+
+// SetGray16 sets the 16 bits at offset off with the gray code of val, in big
+// endian.
+func (c Checked) SetGray16(off int, val uint16) error { return c.SetUint16(off, val^val>>1) }
+
+// SetGray32 sets the 32 bits at offset off with the gray code of val, in big
+// endian.
+func (c Checked) SetGray32(off int, val uint32) error { return c.SetUint32(off, val^val>>1) }
+
+// SetGray64 sets the 64 bits at offset off with the gray code of val, in big
+// endian.
+func (c Checked) SetGray64(off int, val uint64) error { return c.SetUint64(off, val^val>>1) }
+
+// SetGrayn sets the n bits at offset off with the gray code of the n-bit
+// unsigned integer val, in big endian. n must be in [1, 64] and val must fit
+// in n bits.
+func (c Checked) SetGrayn(off, n int, val uint64) error {
+	if err := checkUintn(n, val); err != nil {
+		return err
+	}
+	return c.SetUintn(off, n, val^val>>1)
+}
+
+/* floating-point */
+
+// Float32 interprets the 32 bits at offset off as an IEEE 754 binary32
+// floating-point number in big endian and returns its value.
+func (c Checked) Float32(off int) (float32, error) {
+	if err := c.bs.checkRange(off, 32); err != nil {
+		return 0, err
+	}
+	return c.bs.Float32(off), nil
+}
+
+// Float64 interprets the 64 bits at offset off as an IEEE 754 binary64
+// floating-point number in big endian and returns its value.
+func (c Checked) Float64(off int) (float64, error) {
+	if err := c.bs.checkRange(off, 64); err != nil {
+		return 0, err
+	}
+	return c.bs.Float64(off), nil
+}
+
+// SetFloat32 sets the 32 bits at offset off with the IEEE 754 binary32
+// representation of val, in big endian.
+func (c Checked) SetFloat32(off int, val float32) error {
+	if err := c.bs.checkRange(off, 32); err != nil {
+		return err
+	}
+	c.bs.SetFloat32(off, val)
+	return nil
+}
+
+// SetFloat64 sets the 64 bits at offset off with the IEEE 754 binary64
+// representation of val, in big endian.
+func (c Checked) SetFloat64(off int, val float64) error {
+	if err := c.bs.checkRange(off, 64); err != nil {
+		return err
+	}
+	c.bs.SetFloat64(off, val)
+	return nil
+}
+
+// GrayFloat32 interprets the 32 bits at offset off as a float32 stored with
+// SetGrayFloat32 and returns its value.
+func (c Checked) GrayFloat32(off int) (float32, error) {
+	if err := c.bs.checkRange(off, 32); err != nil {
+		return 0, err
+	}
+	return c.bs.GrayFloat32(off), nil
+}
+
+// GrayFloat64 interprets the 64 bits at offset off as a float64 stored with
+// SetGrayFloat64 and returns its value.
+func (c Checked) GrayFloat64(off int) (float64, error) {
+	if err := c.bs.checkRange(off, 64); err != nil {
+		return 0, err
+	}
+	return c.bs.GrayFloat64(off), nil
+}
+
+// SetGrayFloat32 sets the 32 bits at offset off with val, in gray-coded float
+// mode, in big endian.
+func (c Checked) SetGrayFloat32(off int, val float32) error {
+	if err := c.bs.checkRange(off, 32); err != nil {
+		return err
+	}
+	c.bs.SetGrayFloat32(off, val)
+	return nil
+}
+
+// SetGrayFloat64 sets the 64 bits at offset off with val, in gray-coded float
+// mode, in big endian.
+func (c Checked) SetGrayFloat64(off int, val float64) error {
+	if err := c.bs.checkRange(off, 64); err != nil {
+		return err
+	}
+	c.bs.SetGrayFloat64(off, val)
+	return nil
+}
+
+// Float interprets the f.Width() bits at offset off as a floating-point number
+// in the f format, in big endian, and returns its value.
+func (c Checked) Float(off int, f FloatFormat) (float64, error) {
+	if err := f.check(); err != nil {
+		return 0, err
+	}
+	if err := c.bs.checkRange(off, f.Width()); err != nil {
+		return 0, err
+	}
+	return c.bs.Float(off, f), nil
+}
+
+// SetFloat sets the f.Width() bits at offset off with val converted to the f
+// format, in big endian.
+func (c Checked) SetFloat(off int, f FloatFormat, val float64) error {
+	if err := f.check(); err != nil {
+		return err
+	}
+	if err := c.bs.checkRange(off, f.Width()); err != nil {
+		return err
+	}
+	c.bs.SetFloat(off, f, val)
+	return nil
+}
+
+/* field orders */
+
+// UintnOrder interprets the n bits at offset off, in the given order, as an
+// n-bit unsigned integer and returns its value. n must be in [1, 64].
+func (c Checked) UintnOrder(off, n int, order FieldOrder) (uint64, error) {
+	if err := c.checkOrder(off, n, order); err != nil {
+		return 0, err
+	}
+	return c.bs.UintnOrder(off, n, order), nil
+}
+
+// IntnOrder interprets the n bits at offset off, in the given order, as an
+// n-bit signed integer and returns its value. n must be in [1, 64].
+func (c Checked) IntnOrder(off, n int, order FieldOrder) (int64, error) {
+	if err := c.checkOrder(off, n, order); err != nil {
+		return 0, err
+	}
+	return c.bs.IntnOrder(off, n, order), nil
+}
+
+// SetUintnOrder sets the n bits at offset off, in the given order, with the
+// given n-bit unsigned integer. n must be in [1, 64] and val must fit in n
+// bits.
+func (c Checked) SetUintnOrder(off, n int, val uint64, order FieldOrder) error {
+	if err := c.checkOrder(off, n, order); err != nil {
+		return err
+	}
+	if err := checkUintn(n, val); err != nil {
+		return err
+	}
+	c.bs.SetUintnOrder(off, n, val, order)
+	return nil
+}
+
+// SetIntnOrder sets the n bits at offset off, in the given order, with the
+// given n-bit signed integer. n must be in [1, 64] and val must fit in n bits.
+func (c Checked) SetIntnOrder(off, n int, val int64, order FieldOrder) error {
+	if err := c.checkOrder(off, n, order); err != nil {
+		return err
+	}
+	if err := checkIntn(n, val); err != nil {
+		return err
+	}
+	c.bs.SetIntnOrder(off, n, val, order)
+	return nil
+}
+
+func (c Checked) checkOrder(off, n int, order FieldOrder) error {
+	if err := order.check(n); err != nil {
+		return err
+	}
+	// Both numberings cover the same ranges.
+	return c.bs.checkField(off, n, 64)
+}
+
+/* fixed-point and BCD */
+
+// Fixed interprets the intBits+fracBits bits at offset off as a fixed-point
+// number in big endian and returns its value, see Bitstring.Fixed.
+// intBits+fracBits must be in [1, 64].
+func (c Checked) Fixed(off, intBits, fracBits int, signed bool) (float64, error) {
+	if err := c.bs.checkField(off, intBits+fracBits, 64); err != nil {
+		return 0, err
+	}
+	return c.bs.Fixed(off, intBits, fracBits, signed), nil
+}
+
+// SetFixed sets the intBits+fracBits bits at offset off with val converted to
+// a fixed-point number, see Bitstring.SetFixed. intBits+fracBits must be in
+// [1, 64].
+func (c Checked) SetFixed(off, intBits, fracBits int, signed bool, val float64) error {
+	if err := c.bs.checkField(off, intBits+fracBits, 64); err != nil {
+		return err
+	}
+	return c.bs.SetFixed(off, intBits, fracBits, signed, val)
+}
+
+// BCD interprets the 4*digits bits at offset off as a binary-coded decimal
+// number, see Bitstring.BCD. digits must be in [1, 19].
+func (c Checked) BCD(off, digits int) (uint64, error) {
+	if err := c.checkBCD(off, digits); err != nil {
+		return 0, err
+	}
+	return c.bs.BCD(off, digits)
+}
+
+// SetBCD sets the 4*digits bits at offset off with val encoded as a
+// binary-coded decimal number, see Bitstring.SetBCD. digits must be in [1,
+// 19].
+func (c Checked) SetBCD(off, digits int, val uint64) error {
+	if err := c.checkBCD(off, digits); err != nil {
+		return err
+	}
+	return c.bs.SetBCD(off, digits, val)
+}
+
+func (c Checked) checkBCD(off, digits int) error {
+	if digits < 1 || digits > maxBCDDigits {
+		return fmt.Errorf("%w: %d BCD digits, must be in [1, %d]", ErrWidth, digits, maxBCDDigits)
+	}
+	return c.bs.checkRange(off, 4*digits)
+}
+
+/* ranges */
+
+// SetRange sets to one all bits in the range [off, off+len).
+func (c Checked) SetRange(off, len int) error {
+	if err := c.bs.checkRange(off, len); err != nil {
+		return err
+	}
+	c.bs.SetRange(off, len)
+	return nil
+}
+
+// ClearRange sets to zero all bits in the range [off, off+len).
+func (c Checked) ClearRange(off, len int) error {
+	if err := c.bs.checkRange(off, len); err != nil {
+		return err
+	}
+	c.bs.ClearRange(off, len)
+	return nil
+}
+
+// FlipRange flips all bits in the range [off, off+len).
+func (c Checked) FlipRange(off, len int) error {
+	if err := c.bs.checkRange(off, len); err != nil {
+		return err
+	}
+	c.bs.FlipRange(off, len)
+	return nil
+}
+
+// OnesCountRange counts the number of one bits in the range [off, off+len).
+func (c Checked) OnesCountRange(off, len int) (int, error) {
+	if err := c.bs.checkRange(off, len); err != nil {
+		return 0, err
+	}
+	return c.bs.OnesCountRange(off, len), nil
+}
+
+// ZeroesCountRange counts the number of zero bits in the range [off, off+len).
+func (c Checked) ZeroesCountRange(off, len int) (int, error) {
+	if err := c.bs.checkRange(off, len); err != nil {
+		return 0, err
+	}
+	return c.bs.ZeroesCountRange(off, len), nil
+}
+
+// CopyRange returns a new Bitstring with a copy of the bits in the [off,
+// off+len) range.
+func (c Checked) CopyRange(off, len int) (*Bitstring, error) {
+	if err := c.bs.checkRange(off, len); err != nil {
+		return nil, err
+	}
+	return c.bs.CopyRange(off, len), nil
+}
+
+// Slice returns a CheckedView over the bits in the [off, off+len) range. Call
+// Unchecked on it to get the View.
+func (c Checked) Slice(off, len int) (CheckedView, error) {
+	if err := c.bs.checkRange(off, len); err != nil {
+		return CheckedView{}, err
+	}
+	return c.bs.Slice(off, len).Checked(), nil
+}
+
+// InsertZeroes inserts n zero bits at offset off, growing the Bitstring by n
+// bits. off must be in [0, Len()].
+func (c Checked) InsertZeroes(off, n int) error {
+	if err := c.bs.checkRange(off, 0); err != nil {
+		return err
+	}
+	if err := c.bs.checkGrow(n); err != nil {
+		return err
+	}
+	c.bs.InsertZeroes(off, n)
+	return nil
+}
+
+// InsertRange inserts all the bits of src at offset off, growing the
+// Bitstring by src.Len() bits. off must be in [0, Len()].
+func (c Checked) InsertRange(off int, src *Bitstring) error {
+	if err := c.bs.checkRange(off, 0); err != nil {
+		return err
+	}
+	if err := c.bs.checkGrow(src.length); err != nil {
+		return err
+	}
+	c.bs.InsertRange(off, src)
+	return nil
+}
+
+// DeleteRange removes the bits in the [off, off+len) range, shrinking the
+// Bitstring by len bits.
+func (c Checked) DeleteRange(off, len int) error {
+	if err := c.bs.checkRange(off, len); err != nil {
+		return err
+	}
+	c.bs.DeleteRange(off, len)
+	return nil
+}
+
+/* split and repeat */
+
+// Split splits the Bitstring into consecutive new Bitstrings of the given
+// sizes, see Bitstring.Split. Sizes must not be negative and must add up to at
+// most Len().
+func (c Checked) Split(sizes ...int) ([]*Bitstring, error) {
+	total := 0
+	for _, size := range sizes {
+		if err := checkCount(size); err != nil {
+			return nil, err
+		}
+		if size > c.bs.length-total {
+			return nil, fmt.Errorf("%w: sizes exceed length %d", ErrOutOfRange, c.bs.length)
+		}
+		total += size
+	}
+	return c.bs.Split(sizes...), nil
+}
+
+// Repeat returns a new Bitstring made of count copies of the Bitstring. count
+// must not be negative.
+func (c Checked) Repeat(count int) (*Bitstring, error) {
+	if err := checkCount(count); err != nil {
+		return nil, err
+	}
+	if count != 0 && c.bs.length > maxLength/count {
+		return nil, fmt.Errorf("%w: %d copies of %d bits exceed %d", ErrOutOfRange, count, c.bs.length, maxLength)
+	}
+	return Repeat(c.bs, count), nil
+}
+
+/* length and shifts */
+
+// Grow grows the capacity of the Bitstring, if necessary, to guarantee space
+// for another n bits. n must not be negative.
+func (c Checked) Grow(n int) error {
+	if err := c.bs.checkGrow(n); err != nil {
+		return err
+	}
+	c.bs.Grow(n)
+	return nil
+}
+
+// Resize changes the length of the Bitstring to length bits, see
+// Bitstring.Resize. length must not be negative.
+func (c Checked) Resize(length int) error {
+	if err := checkLength(length); err != nil {
+		return err
+	}
+	c.bs.Resize(length)
+	return nil
+}
+
+// Truncate discards all but the first length bits of the Bitstring. length
+// must be in [0, Len()].
+func (c Checked) Truncate(length int) error {
+	if err := c.bs.checkRange(0, length); err != nil {
+		return err
+	}
+	c.bs.Truncate(length)
+	return nil
+}
+
+// AppendUintn appends the n-bit unsigned integer val to the Bitstring, growing
+// it by n bits. n must be in [1, 64] and val must fit in n bits.
+func (c Checked) AppendUintn(val uint64, n int) error {
+	if n < 1 || n > 64 {
+		return fmt.Errorf("%w: %d bits, must be in [1, 64]", ErrWidth, n)
+	}
+	if err := checkUintn(n, val); err != nil {
+		return err
+	}
+	if err := c.bs.checkGrow(n); err != nil {
+		return err
+	}
+	c.bs.AppendUintn(val, n)
+	return nil
+}
+
+// ShiftLeft shifts the Bitstring by n bits towards the most significant end,
+// see Bitstring.ShiftLeft. n must not be negative.
+func (c Checked) ShiftLeft(n int) error {
+	if err := checkCount(n); err != nil {
+		return err
+	}
+	c.bs.ShiftLeft(n)
+	return nil
+}
+
+// ShiftLeftOnes is like ShiftLeft except that the n least significant bits are
+// set to 1.
+func (c Checked) ShiftLeftOnes(n int) error {
+	if err := checkCount(n); err != nil {
+		return err
+	}
+	c.bs.ShiftLeftOnes(n)
+	return nil
+}
+
+// ShiftRight shifts the Bitstring by n bits towards the least significant end,
+// see Bitstring.ShiftRight. n must not be negative.
+func (c Checked) ShiftRight(n int) error {
+	if err := checkCount(n); err != nil {
+		return err
+	}
+	c.bs.ShiftRight(n)
+	return nil
+}
+
+// ShiftRightOnes is like ShiftRight except that the n most significant bits
+// are set to 1.
+func (c Checked) ShiftRightOnes(n int) error {
+	if err := checkCount(n); err != nil {
+		return err
+	}
+	c.bs.ShiftRightOnes(n)
+	return nil
+}
+
+// ShiftRightArith is like ShiftRight except that the n most significant bits
+// are copies of the sign bit, see Bitstring.ShiftRightArith. n must not be
+// negative.
+func (c Checked) ShiftRightArith(n int) error {
+	if err := checkCount(n); err != nil {
+		return err
+	}
+	c.bs.ShiftRightArith(n)
+	return nil
+}
+
+/* operations on 2 bitstrings */
+
+// EqualRange compares the [off, off+len) range of bits with the same range
+// of other. The range must exist on both bitstrings.
+func (c Checked) EqualRange(other *Bitstring, off, len int) (bool, error) {
+	if err := c.bs.checkRange(off, len); err != nil {
+		return false, err
+	}
+	if err := other.checkRange(off, len); err != nil {
+		return false, err
+	}
+	return EqualRange(c.bs, other, off, len), nil
+}
+
+// EqualRangeAt compares n bits starting at offset off with n bits of other,
+// starting at offset otherOff. Both ranges must exist.
+func (c Checked) EqualRangeAt(off int, other *Bitstring, otherOff, n int) (bool, error) {
+	if err := c.bs.checkRange(off, n); err != nil {
+		return false, err
+	}
+	if err := other.checkRange(otherOff, n); err != nil {
+		return false, err
+	}
+	return EqualRangeAt(c.bs, off, other, otherOff, n), nil
+}
+
+// SwapRange swaps the [off, off+len) range of bits with the same range of
+// other. Both bitstrings must have the same length and the range must exist.
+func (c Checked) SwapRange(other *Bitstring, off, len int) error {
+	if err := checkSameLength(c.bs, other); err != nil {
+		return err
+	}
+	if err := c.bs.checkRange(off, len); err != nil {
+		return err
+	}
+	if err := other.checkRange(off, len); err != nil {
+		return err
+	}
+	SwapRange(c.bs, other, off, len)
+	return nil
+}
+
+// CopyBits copies n bits of src, starting at offset srcOff, to the
+// Bitstring at offset off. Both ranges must exist, they may overlap.
+func (c Checked) CopyBits(off int, src *Bitstring, srcOff, n int) error {
+	if err := c.bs.checkRange(off, n); err != nil {
+		return err
+	}
+	if err := src.checkRange(srcOff, n); err != nil {
+		return err
+	}
+	CopyBits(c.bs, off, src, srcOff, n)
+	return nil
+}
+
+// And sets the Bitstring to the bitwise AND of x and y, which must have the
+// same length.
+func (c Checked) And(x, y *Bitstring) error {
+	if err := checkSameLength(x, y); err != nil {
+		return err
+	}
+	c.bs.And(x, y)
+	return nil
+}
+
+// Or sets the Bitstring to the bitwise OR of x and y, which must have the same
+// length.
+func (c Checked) Or(x, y *Bitstring) error {
+	if err := checkSameLength(x, y); err != nil {
+		return err
+	}
+	c.bs.Or(x, y)
+	return nil
+}
+
+// Xor sets the Bitstring to the bitwise XOR of x and y, which must have the
+// same length.
+func (c Checked) Xor(x, y *Bitstring) error {
+	if err := checkSameLength(x, y); err != nil {
+		return err
+	}
+	c.bs.Xor(x, y)
+	return nil
+}
+
+// AndNot sets the Bitstring to the bitwise AND NOT of x and y, which must have
+// the same length.
+func (c Checked) AndNot(x, y *Bitstring) error {
+	if err := checkSameLength(x, y); err != nil {
+		return err
+	}
+	c.bs.AndNot(x, y)
+	return nil
+}
@@ -0,0 +1,415 @@
+package bitstring
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRange(t *testing.T) {
+	bs := New(100)
+
+	tests := []struct {
+		off, n  int
+		wantErr error
+	}{
+		{0, 0, nil},
+		{0, 100, nil},
+		{100, 0, nil},
+		{36, 64, nil},
+		{99, 1, nil},
+		{-1, 1, ErrNegativeOffset},
+		{-1, 0, ErrNegativeOffset},
+		{0, -1, ErrWidth},
+		{101, 0, ErrOutOfRange},
+		{37, 64, ErrOutOfRange},
+		{100, 1, ErrOutOfRange},
+		{1, math.MaxInt, ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("off=%d,n=%d", tt.off, tt.n), func(t *testing.T) {
+			err := bs.checkRange(tt.off, tt.n)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckedBit(t *testing.T) {
+	bs, _ := NewFromString("0100")
+	c := bs.Checked()
+	assert.Equal(t, 4, c.Len())
+	assert.True(t, c.Unchecked() == bs)
+
+	got, err := c.Bit(2)
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	_, err = c.Bit(4)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = c.Bit(-1)
+	assert.ErrorIs(t, err, ErrNegativeOffset)
+
+	require.NoError(t, c.SetBit(0))
+	require.NoError(t, c.FlipBit(3))
+	require.NoError(t, c.ClearBit(2))
+	assert.ErrorIs(t, c.SetBit(4), ErrOutOfRange)
+	assert.ErrorIs(t, c.ClearBit(-4), ErrNegativeOffset)
+	assert.ErrorIs(t, c.FlipBit(100), ErrOutOfRange)
+	assert.Equal(t, "1001", bs.String())
+}
+
+func TestCheckedIntegers(t *testing.T) {
+	bs := New(70)
+	c := bs.Checked()
+
+	require.NoError(t, c.SetUint64(6, 0x0123456789abcdef))
+	v64, err := c.Uint64(6)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x0123456789abcdef), v64)
+
+	require.NoError(t, c.SetInt16(54, -2))
+	v16, err := c.Int16(54)
+	require.NoError(t, err)
+	assert.Equal(t, int16(-2), v16)
+
+	require.NoError(t, c.SetIntn(3, 5, -16))
+	vn, err := c.Intn(3, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-16), vn)
+
+	require.NoError(t, c.SetUintn(0, 3, 7))
+	un, err := c.Uintn(0, 3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), un)
+
+	// Errors leave bs untouched.
+	want := bs.Clone()
+
+	assert.ErrorIs(t, c.SetUint64(7, 0), ErrOutOfRange)
+	assert.ErrorIs(t, c.SetUint32(-1, 0), ErrNegativeOffset)
+	assert.ErrorIs(t, c.SetUint8(63, 0), ErrOutOfRange)
+	assert.ErrorIs(t, c.SetInt64(7, 0), ErrOutOfRange)
+	assert.ErrorIs(t, c.SetUintn(0, 65, 0), ErrWidth)
+	assert.ErrorIs(t, c.SetUintn(0, 0, 0), ErrWidth)
+	assert.ErrorIs(t, c.SetUintn(0, 3, 8), ErrOverflow)
+	assert.ErrorIs(t, c.SetIntn(0, 3, 4), ErrOverflow)
+	assert.ErrorIs(t, c.SetIntn(-3, 3, 1), ErrNegativeOffset)
+	equalbits(t, bs, want)
+
+	_, err = c.Uint16(55)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = c.Int32(39)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = c.Int8(-8)
+	assert.ErrorIs(t, err, ErrNegativeOffset)
+	_, err = c.Intn(0, 65)
+	assert.ErrorIs(t, err, ErrWidth)
+	_, err = c.Uintn(60, 11)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+func TestCheckedGrayAndFloats(t *testing.T) {
+	bs := New(64)
+	c := bs.Checked()
+
+	require.NoError(t, c.SetGrayn(3, 10, 1000))
+	g, err := c.Grayn(3, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), g)
+	assert.ErrorIs(t, c.SetGrayn(3, 10, 1024), ErrOverflow)
+	assert.ErrorIs(t, c.SetGray8(57, 1), ErrOutOfRange)
+	_, err = c.Gray64(1)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	require.NoError(t, c.SetFloat32(32, 1.5))
+	f32, err := c.Float32(32)
+	require.NoError(t, err)
+	assert.Equal(t, float32(1.5), f32)
+	assert.ErrorIs(t, c.SetFloat64(1, 1), ErrOutOfRange)
+
+	require.NoError(t, c.SetFloat(0, Binary16, -2))
+	f, err := c.Float(0, Binary16)
+	require.NoError(t, err)
+	assert.Equal(t, -2.0, f)
+	assert.ErrorIs(t, c.SetFloat(49, Binary16, 1), ErrOutOfRange)
+	_, err = c.Float(0, FloatFormat{ExpBits: 12, MantBits: 3})
+	assert.ErrorIs(t, err, ErrWidth)
+
+	require.NoError(t, c.SetGrayFloat32(32, -0.25))
+	gf32, err := c.GrayFloat32(32)
+	require.NoError(t, err)
+	assert.Equal(t, float32(-0.25), gf32)
+	require.NoError(t, c.SetGrayFloat64(0, 3.5))
+	gf64, err := c.GrayFloat64(0)
+	require.NoError(t, err)
+	assert.Equal(t, 3.5, gf64)
+	assert.ErrorIs(t, c.SetGrayFloat32(33, 1), ErrOutOfRange)
+	assert.ErrorIs(t, c.SetGrayFloat64(-1, 1), ErrNegativeOffset)
+	_, err = c.GrayFloat32(40)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = c.GrayFloat64(1)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+func TestCheckedOrderFixedBCD(t *testing.T) {
+	bs := New(32)
+	c := bs.Checked()
+
+	require.NoError(t, c.SetUintnOrder(0, 16, 0x1234, MSB0|LittleEndian))
+	v, err := c.UintnOrder(0, 16, MSB0|LittleEndian)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x1234), v)
+	assert.Equal(t, uint64(0x3412), bs.Uintn(16, 16))
+
+	assert.ErrorIs(t, c.SetUintnOrder(0, 12, 1, LittleEndian), ErrWidth)
+	assert.ErrorIs(t, c.SetUintnOrder(20, 16, 1, MSB0), ErrOutOfRange)
+	assert.ErrorIs(t, c.SetUintnOrder(0, 8, 256, MSB0), ErrOverflow)
+	assert.ErrorIs(t, c.SetIntnOrder(0, 8, 128, LSB0), ErrOverflow)
+	_, err = c.IntnOrder(-1, 8, MSB0)
+	assert.ErrorIs(t, err, ErrNegativeOffset)
+	_, err = c.UintnOrder(0, 8, FieldOrder(4))
+	assert.Error(t, err)
+
+	require.NoError(t, c.SetFixed(0, 4, 4, true, -1.5))
+	x, err := c.Fixed(0, 4, 4, true)
+	require.NoError(t, err)
+	assert.Equal(t, -1.5, x)
+	assert.ErrorIs(t, c.SetFixed(0, 4, 4, true, 8), ErrOverflow)
+	assert.ErrorIs(t, c.SetFixed(0, 40, 40, true, 0), ErrWidth)
+	_, err = c.Fixed(30, 1, 2, false)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	require.NoError(t, c.SetBCD(8, 4, 1234))
+	d, err := c.BCD(8, 4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1234), d)
+	assert.ErrorIs(t, c.SetBCD(8, 7, 1), ErrOutOfRange)
+	assert.ErrorIs(t, c.SetBCD(0, 20, 1), ErrWidth)
+	_, err = c.BCD(0, 0)
+	assert.ErrorIs(t, err, ErrWidth)
+}
+
+func TestCheckedRanges(t *testing.T) {
+	bs, _ := NewFromString("0000111100001111")
+	c := bs.Checked()
+
+	require.NoError(t, c.FlipRange(2, 4))
+	require.NoError(t, c.SetRange(14, 2))
+	require.NoError(t, c.ClearRange(0, 1))
+	require.NoError(t, c.SetRange(16, 0))
+	assert.Equal(t, "1100111100110010", bs.String())
+
+	ones, err := c.OnesCountRange(0, 16)
+	require.NoError(t, err)
+	assert.Equal(t, 9, ones)
+	zeroes, err := c.ZeroesCountRange(4, 8)
+	require.NoError(t, err)
+	assert.Equal(t, 2, zeroes)
+
+	cpy, err := c.CopyRange(4, 8)
+	require.NoError(t, err)
+	assert.Equal(t, "11110011", cpy.String())
+	v, err := c.Slice(4, 8)
+	require.NoError(t, err)
+	assert.Equal(t, "11110011", v.Unchecked().String())
+
+	want := bs.Clone()
+	assert.ErrorIs(t, c.SetRange(10, 7), ErrOutOfRange)
+	assert.ErrorIs(t, c.ClearRange(-1, 2), ErrNegativeOffset)
+	assert.ErrorIs(t, c.FlipRange(0, -1), ErrWidth)
+	assert.ErrorIs(t, c.DeleteRange(15, 2), ErrOutOfRange)
+	assert.ErrorIs(t, c.InsertZeroes(17, 1), ErrOutOfRange)
+	assert.ErrorIs(t, c.InsertZeroes(0, -1), ErrWidth)
+	assert.ErrorIs(t, c.InsertRange(-1, New(1)), ErrNegativeOffset)
+	_, err = c.OnesCountRange(17, 0)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = c.ZeroesCountRange(0, 17)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = c.CopyRange(-2, 1)
+	assert.ErrorIs(t, err, ErrNegativeOffset)
+	_, err = c.Slice(8, 9)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	equalbits(t, bs, want)
+
+	require.NoError(t, c.InsertZeroes(16, 2))
+	require.NoError(t, c.InsertRange(0, New(1)))
+	require.NoError(t, c.DeleteRange(1, 4))
+	assert.Equal(t, "001100111100110", bs.String())
+
+	// Empty ranges are valid up to Len(), even on a word boundary.
+	assert.NoError(t, New(64).Checked().FlipRange(64, 0))
+}
+
+func TestCheckedSplitRepeat(t *testing.T) {
+	bs, _ := NewFromString("110010")
+	c := bs.Checked()
+
+	parts, err := c.Split(2, 3)
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+	assert.Equal(t, "10", parts[0].String())
+	assert.Equal(t, "100", parts[1].String())
+	assert.Equal(t, "1", parts[2].String())
+	_, err = c.Split(2, -1)
+	assert.ErrorIs(t, err, ErrWidth)
+	_, err = c.Split(4, 3)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = c.Split(1, math.MaxInt)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	rep, err := c.Repeat(2)
+	require.NoError(t, err)
+	assert.Equal(t, "110010110010", rep.String())
+	rep, err = New(0).Checked().Repeat(math.MaxInt)
+	require.NoError(t, err)
+	assert.Equal(t, 0, rep.Len())
+	_, err = c.Repeat(-1)
+	assert.ErrorIs(t, err, ErrWidth)
+	_, err = c.Repeat(math.MaxInt / 4)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+func TestCheckedGeneric(t *testing.T) {
+	bs := New(40)
+
+	require.NoError(t, SetChecked(bs, 8, int16(-2)))
+	require.NoError(t, SetNChecked(bs, 0, 5, uint8(31)))
+	i16, err := GetChecked[int16](bs, 8)
+	require.NoError(t, err)
+	assert.Equal(t, int16(-2), i16)
+	i8, err := GetNChecked[int8](bs, 0, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int8(-1), i8)
+
+	want := bs.Clone()
+	_, err = GetChecked[uint64](bs, 0)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = GetNChecked[uint8](bs, 0, 9)
+	assert.ErrorIs(t, err, ErrWidth)
+	_, err = GetNChecked[int](bs, -1, 2)
+	assert.ErrorIs(t, err, ErrNegativeOffset)
+	assert.ErrorIs(t, SetChecked(bs, 30, uint16(1)), ErrOutOfRange)
+	assert.ErrorIs(t, SetNChecked(bs, 0, 0, 1), ErrWidth)
+	assert.ErrorIs(t, SetNChecked(bs, 0, 4, uint8(16)), ErrOverflow)
+	assert.ErrorIs(t, SetNChecked(bs, 0, 4, int8(-9)), ErrOverflow)
+	equalbits(t, bs, want)
+}
+
+func TestCheckedLengthAndShifts(t *testing.T) {
+	bs, _ := NewFromString("10110")
+	c := bs.Checked()
+
+	want := bs.Clone()
+	assert.ErrorIs(t, c.Grow(-1), ErrWidth)
+	assert.ErrorIs(t, c.Resize(-1), ErrWidth)
+	assert.ErrorIs(t, c.Truncate(-1), ErrWidth)
+	assert.ErrorIs(t, c.Truncate(6), ErrOutOfRange)
+	assert.ErrorIs(t, c.AppendUintn(1, 0), ErrWidth)
+	assert.ErrorIs(t, c.AppendUintn(1, 65), ErrWidth)
+	assert.ErrorIs(t, c.AppendUintn(4, 2), ErrOverflow)
+	for _, shift := range []func(int) error{c.ShiftLeft, c.ShiftLeftOnes, c.ShiftRight, c.ShiftRightOnes, c.ShiftRightArith} {
+		assert.ErrorIs(t, shift(-1), ErrWidth)
+	}
+	equalbits(t, bs, want)
+
+	require.NoError(t, c.ShiftLeft(1))
+	assert.Equal(t, "01100", bs.String())
+	require.NoError(t, c.ShiftRightOnes(2))
+	assert.Equal(t, "11011", bs.String())
+	require.NoError(t, c.ShiftRightArith(1))
+	assert.Equal(t, "11101", bs.String())
+	require.NoError(t, c.ShiftLeftOnes(3))
+	assert.Equal(t, "01111", bs.String())
+	require.NoError(t, c.ShiftRight(4))
+	assert.Equal(t, "00000", bs.String())
+
+	require.NoError(t, c.AppendUintn(3, 2))
+	assert.Equal(t, "1100000", bs.String())
+	require.NoError(t, c.Truncate(3))
+	require.NoError(t, c.Grow(100))
+	assert.Equal(t, 3, bs.Len())
+	require.NoError(t, c.Resize(70))
+	assert.Equal(t, 70, bs.Len())
+	require.NoError(t, c.Truncate(70))
+	assert.Equal(t, 70, bs.Len())
+
+	// Lengths can't overflow.
+	want = bs.Clone()
+	assert.ErrorIs(t, c.Resize(math.MaxInt), ErrOutOfRange)
+	assert.ErrorIs(t, c.Resize(math.MaxInt-62), ErrOutOfRange)
+	assert.ErrorIs(t, c.Grow(math.MaxInt-5), ErrOutOfRange)
+	assert.ErrorIs(t, c.InsertZeroes(0, math.MaxInt-5), ErrOutOfRange)
+	assert.ErrorIs(t, c.InsertZeroes(70, math.MaxInt), ErrOutOfRange)
+	huge := &Bitstring{length: math.MaxInt - 64}
+	assert.ErrorIs(t, huge.Checked().AppendUintn(0, 64), ErrOutOfRange)
+	assert.ErrorIs(t, huge.Checked().InsertRange(0, bs), ErrOutOfRange)
+	equalbits(t, bs, want)
+}
+
+func TestCheckedTwoBitstrings(t *testing.T) {
+	a, _ := NewFromString("11110000")
+	b, _ := NewFromString("1000")
+	c := a.Checked()
+
+	eq, err := c.EqualRangeAt(6, b, 2, 2)
+	require.NoError(t, err)
+	assert.False(t, eq)
+	eq, err = c.EqualRange(b, 0, 3)
+	require.NoError(t, err)
+	assert.True(t, eq)
+
+	_, err = c.EqualRange(b, 2, 4)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = c.EqualRangeAt(0, b, -1, 2)
+	assert.ErrorIs(t, err, ErrNegativeOffset)
+	assert.ErrorIs(t, c.SwapRange(b, 0, 4), ErrLengthMismatch)
+	assert.ErrorIs(t, c.CopyBits(6, b, 0, 3), ErrOutOfRange)
+	assert.ErrorIs(t, c.CopyBits(0, b, 3, 2), ErrOutOfRange)
+
+	other, _ := NewFromString("00001000")
+	assert.ErrorIs(t, c.SwapRange(other, 5, 4), ErrOutOfRange)
+	require.NoError(t, c.SwapRange(other, 0, 4))
+	assert.Equal(t, "11111000", a.String())
+	assert.Equal(t, "00000000", other.String())
+	require.NoError(t, c.CopyBits(4, b, 0, 4))
+	assert.Equal(t, "10001000", a.String())
+
+	x, _ := NewFromString("1100")
+	y, _ := NewFromString("1010")
+	dst := New(4).Checked()
+	require.NoError(t, dst.And(x, y))
+	assert.Equal(t, "1000", dst.Unchecked().String())
+	require.NoError(t, dst.Or(x, y))
+	assert.Equal(t, "1110", dst.Unchecked().String())
+	require.NoError(t, dst.Xor(x, y))
+	assert.Equal(t, "0110", dst.Unchecked().String())
+	require.NoError(t, dst.AndNot(x, y))
+	assert.Equal(t, "0100", dst.Unchecked().String())
+
+	for _, op := range []func(x, y *Bitstring) error{dst.And, dst.Or, dst.Xor, dst.AndNot} {
+		assert.True(t, errors.Is(op(x, a), ErrLengthMismatch))
+	}
+}
+
+func ExampleBitstring_Checked() {
+	packet, _ := NewFromString("1010010111110000")
+	c := packet.Checked()
+
+	if _, err := c.Uint16(8); err != nil {
+		fmt.Println(err)
+	}
+	if v, err := c.Uint8(8); err == nil {
+		fmt.Printf("%#x\n", v)
+	}
+	// Output:
+	// bitstring: out of range: range [8, 24), length 16
+	// 0xa5
+}
@@ -0,0 +1,273 @@
+package bitstring
+
+// CheckedView is the Checked counterpart of View. Its methods validate their
+// arguments against the View range, and that this range still exists in the
+// parent, and return an error instead of having undefined behavior. When a
+// method returns an error, the parent is left untouched.
+//
+// Methods that take no offset, such as OnesCount or Equals, have no
+// CheckedView counterpart: call them on Unchecked().
+type CheckedView struct {
+	v View
+}
+
+// Checked returns a CheckedView wrapper around v.
+func (v View) Checked() CheckedView { return CheckedView{v: v} }
+
+// Unchecked returns the underlying View.
+func (c CheckedView) Unchecked() View { return c.v }
+
+// Len returns the length of the underlying View.
+func (c CheckedView) Len() int { return c.v.length }
+
+// Slice returns a CheckedView over the bits in the [off, off+len) range of the
+// View. The returned View shares the same parent.
+func (c CheckedView) Slice(off, len int) (CheckedView, error) {
+	if err := c.v.checkRange(off, len); err != nil {
+		return CheckedView{}, err
+	}
+	return c.v.Slice(off, len).Checked(), nil
+}
+
+/* single bit */
+
+// Bit returns a boolean indicating whether the bit at index i is set.
+func (c CheckedView) Bit(i int) (bool, error) {
+	if err := c.v.checkIndex(i); err != nil {
+		return false, err
+	}
+	return c.v.Bit(i), nil
+}
+
+// SetBit sets the bit at index i.
+func (c CheckedView) SetBit(i int) error {
+	if err := c.v.checkIndex(i); err != nil {
+		return err
+	}
+	c.v.SetBit(i)
+	return nil
+}
+
+// ClearBit clears the bit at index i.
+func (c CheckedView) ClearBit(i int) error {
+	if err := c.v.checkIndex(i); err != nil {
+		return err
+	}
+	c.v.ClearBit(i)
+	return nil
+}
+
+// FlipBit flips the bit at index i.
+func (c CheckedView) FlipBit(i int) error {
+	if err := c.v.checkIndex(i); err != nil {
+		return err
+	}
+	c.v.FlipBit(i)
+	return nil
+}
+
+/* unsigned integer get */
+
+// Uint8 interprets the 8 bits at offset off as an uint8 in big endian and
+// returns its value.
+func (c CheckedView) Uint8(off int) (uint8, error) {
+	if err := c.v.checkRange(off, 8); err != nil {
+		return 0, err
+	}
+	return c.v.Uint8(off), nil
+}
+
+// Uint16 interprets the 16 bits at offset off as an uint16 in big endian and
+// returns its value.
+func (c CheckedView) Uint16(off int) (uint16, error) {
+	if err := c.v.checkRange(off, 16); err != nil {
+		return 0, err
+	}
+	return c.v.Uint16(off), nil
+}
+
+// Uint32 interprets the 32 bits at offset off as an uint32 in big endian and
+// returns its value.
+func (c CheckedView) Uint32(off int) (uint32, error) {
+	if err := c.v.checkRange(off, 32); err != nil {
+		return 0, err
+	}
+	return c.v.Uint32(off), nil
+}
+
+// Uint64 interprets the 64 bits at offset off as an uint64 in big endian and
+// returns its value.
+func (c CheckedView) Uint64(off int) (uint64, error) {
+	if err := c.v.checkRange(off, 64); err != nil {
+		return 0, err
+	}
+	return c.v.Uint64(off), nil
+}
+
+// Uintn interprets the n bits at offset off as an n-bit unsigned integer in big
+// endian and returns its value. n must be in [1, 64].
+func (c CheckedView) Uintn(off, n int) (uint64, error) {
+	if err := c.v.checkField(off, n, 64); err != nil {
+		return 0, err
+	}
+	return c.v.Uintn(off, n), nil
+}
+
+/* unsigned integer set */
+
+// SetUint8 sets the 8 bits at offset off with the given uint8 value, in big
+// endian.
+func (c CheckedView) SetUint8(off int, val uint8) error {
+	if err := c.v.checkRange(off, 8); err != nil {
+		return err
+	}
+	c.v.SetUint8(off, val)
+	return nil
+}
+
+// SetUint16 sets the 16 bits at offset off with the given uint16 value, in big
+// endian.
+func (c CheckedView) SetUint16(off int, val uint16) error {
+	if err := c.v.checkRange(off, 16); err != nil {
+		return err
+	}
+	c.v.SetUint16(off, val)
+	return nil
+}
+
+// SetUint32 sets the 32 bits at offset off with the given uint32 value, in big
+// endian.
+func (c CheckedView) SetUint32(off int, val uint32) error {
+	if err := c.v.checkRange(off, 32); err != nil {
+		return err
+	}
+	c.v.SetUint32(off, val)
+	return nil
+}
+
+// SetUint64 sets the 64 bits at offset off with the given uint64 value, in big
+// endian.
+func (c CheckedView) SetUint64(off int, val uint64) error {
+	if err := c.v.checkRange(off, 64); err != nil {
+		return err
+	}
+	c.v.SetUint64(off, val)
+	return nil
+}
+
+// SetUintn sets the n bits at offset off with the given n-bit unsigned integer
+// in big endian. n must be in [1, 64] and val must fit in n bits.
+func (c CheckedView) SetUintn(off, n int, val uint64) error {
+	if err := c.v.checkField(off, n, 64); err != nil {
+		return err
+	}
+	if err := checkUintn(n, val); err != nil {
+		return err
+	}
+	c.v.SetUintn(off, n, val)
+	return nil
+}
+
+/* signed integer get */
+
+// Int8 interprets the 8 bits at offset off as an int8 in big endian and
+// returns its value.
+func (c CheckedView) Int8(off int) (int8, error) {
+	v, err := c.Uint8(off)
+	return int8(v), err
+}
+
+// Int16 interprets the 16 bits at offset off as an int16 in big endian and
+// returns its value.
+func (c CheckedView) Int16(off int) (int16, error) {
+	v, err := c.Uint16(off)
+	return int16(v), err
+}
+
+// Int32 interprets the 32 bits at offset off as an int32 in big endian and
+// returns its value.
+func (c CheckedView) Int32(off int) (int32, error) {
+	v, err := c.Uint32(off)
+	return int32(v), err
+}
+
+// Int64 interprets the 64 bits at offset off as an int64 in big endian and
+// returns its value.
+func (c CheckedView) Int64(off int) (int64, error) {
+	v, err := c.Uint64(off)
+	return int64(v), err
+}
+
+// Intn interprets the n bits at offset off as an n-bit signed integer in big
+// endian and returns its value, sign-extended. n must be in [1, 64].
+func (c CheckedView) Intn(off, n int) (int64, error) {
+	if err := c.v.checkField(off, n, 64); err != nil {
+		return 0, err
+	}
+	return c.v.Intn(off, n), nil
+}
+
+/* signed integer set */
+
+// SetInt8 sets the 8 bits at offset off with the given int8 value, in big
+// endian.
+func (c CheckedView) SetInt8(off int, val int8) error { return c.SetUint8(off, uint8(val)) }
+
+// SetInt16 sets the 16 bits at offset off with the given int16 value, in big
+// endian.
+func (c CheckedView) SetInt16(off int, val int16) error { return c.SetUint16(off, uint16(val)) }
+
+// SetInt32 sets the 32 bits at offset off with the given int32 value, in big
+// endian.
+func (c CheckedView) SetInt32(off int, val int32) error { return c.SetUint32(off, uint32(val)) }
+
+// SetInt64 sets the 64 bits at offset off with the given int64 value, in big
+// endian.
+func (c CheckedView) SetInt64(off int, val int64) error { return c.SetUint64(off, uint64(val)) }
+
+// SetIntn sets the n bits at offset off with the given n-bit signed integer in
+// big endian. n must be in [1, 64] and val must fit in n bits.
+func (c CheckedView) SetIntn(off, n int, val int64) error {
+	if err := c.v.checkField(off, n, 64); err != nil {
+		return err
+	}
+	return c.v.SetIntnChecked(off, n, val)
+}
+
+/* ranges */
+
+// SetRange sets the bits in the [off, off+len) range.
+func (c CheckedView) SetRange(off, len int) error {
+	if err := c.v.checkRange(off, len); err != nil {
+		return err
+	}
+	c.v.SetRange(off, len)
+	return nil
+}
+
+// ClearRange clears the bits in the [off, off+len) range.
+func (c CheckedView) ClearRange(off, len int) error {
+	if err := c.v.checkRange(off, len); err != nil {
+		return err
+	}
+	c.v.ClearRange(off, len)
+	return nil
+}
+
+// FlipRange flips the bits in the [off, off+len) range.
+func (c CheckedView) FlipRange(off, len int) error {
+	if err := c.v.checkRange(off, len); err != nil {
+		return err
+	}
+	c.v.FlipRange(off, len)
+	return nil
+}
+
+// CopyRange returns a new Bitstring with a copy of the bits in the [off,
+// off+len) range.
+func (c CheckedView) CopyRange(off, len int) (*Bitstring, error) {
+	if err := c.v.checkRange(off, len); err != nil {
+		return nil, err
+	}
+	return c.v.CopyRange(off, len), nil
+}
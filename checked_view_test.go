@@ -0,0 +1,63 @@
+package bitstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckedView(t *testing.T) {
+	bs := New(100)
+	v, err := bs.Checked().Slice(10, 80)
+	require.NoError(t, err)
+	assert.Equal(t, 80, v.Len())
+
+	// Accessors are translated by the View offset.
+	require.NoError(t, v.SetBit(0))
+	require.NoError(t, v.SetUint16(64, 0xabcd))
+	require.NoError(t, v.SetIntn(1, 5, -3))
+	assert.True(t, bs.Bit(10))
+	assert.Equal(t, uint16(0xabcd), bs.Uint16(74))
+	i, err := v.Intn(1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-3), i)
+	u, err := v.Uint8(68)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0xbc), u)
+
+	// A sub-field of the View is checked against the View range.
+	sub, err := v.Slice(64, 16)
+	require.NoError(t, err)
+	got, err := sub.Uint16(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0xabcd), got)
+	_, err = sub.Uint8(9)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = v.Slice(70, 11)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	want := bs.Clone()
+	_, err = v.Bit(80)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.ErrorIs(t, v.FlipBit(-1), ErrNegativeOffset)
+	_, err = v.Uint64(17)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = v.Uintn(0, 65)
+	assert.ErrorIs(t, err, ErrWidth)
+	assert.ErrorIs(t, v.SetUint32(49, 1), ErrOutOfRange)
+	assert.ErrorIs(t, v.SetUintn(0, 4, 16), ErrOverflow)
+	assert.ErrorIs(t, v.SetIntn(0, 4, 8), ErrOverflow)
+	assert.ErrorIs(t, v.SetInt8(73, 1), ErrOutOfRange)
+	assert.ErrorIs(t, v.SetRange(79, 2), ErrOutOfRange)
+	assert.ErrorIs(t, v.ClearRange(0, -1), ErrWidth)
+	_, err = v.CopyRange(81, 0)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	equalbits(t, bs, want)
+
+	// The View is invalid once its parent has shrunk.
+	bs.Truncate(50)
+	_, err = v.Bit(0)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.Contains(t, v.SetBit(0).Error(), "parent has shrunk")
+}
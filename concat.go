@@ -0,0 +1,74 @@
+package bitstring
+
+// Concat returns a new Bitstring made of the concatenation of all the bits of
+// parts. The first part occupies the least significant bits of the result
+// (i.e the rightmost side of the string representation), and each subsequent
+// part is placed immediately above the previous one. That's the same order as
+// AppendBitstring and Split.
+func Concat(parts ...*Bitstring) *Bitstring {
+	length := 0
+	for _, p := range parts {
+		length += p.length
+	}
+
+	ret := New(length)
+	off := 0
+	for _, p := range parts {
+		CopyBits(ret, off, p, 0, p.length)
+		off += p.length
+	}
+	return ret
+}
+
+// Split splits bs into consecutive new Bitstrings of the given sizes, starting
+// from bit 0. If the sizes add up to less than bs.Len(), the remaining bits are
+// returned in an additional final Bitstring. Split is the inverse of Concat.
+//
+// Panics if a size is negative or if the sizes add up to more than bs.Len().
+func (bs *Bitstring) Split(sizes ...int) []*Bitstring {
+	total := 0
+	for _, size := range sizes {
+		if size < 0 {
+			panic("Bitstring.Split: negative size")
+		}
+		total += size
+	}
+	if total > bs.length {
+		panic("Bitstring.Split: sizes exceed bitstring length")
+	}
+
+	parts := make([]*Bitstring, 0, len(sizes)+1)
+	off := 0
+	for _, size := range sizes {
+		p := New(size)
+		CopyBits(p, 0, bs, off, size)
+		parts = append(parts, p)
+		off += size
+	}
+	if off < bs.length {
+		p := New(bs.length - off)
+		CopyBits(p, 0, bs, off, p.length)
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+// Repeat returns a new Bitstring made of count copies of bs. Panics if count
+// is negative.
+func Repeat(bs *Bitstring, count int) *Bitstring {
+	if count < 0 {
+		panic("bitstring.Repeat: negative count")
+	}
+
+	ret := New(bs.length * count)
+	if ret.length == 0 {
+		return ret
+	}
+
+	// Copy bs once, then double the number of copies at each step.
+	CopyBits(ret, 0, bs, 0, bs.length)
+	for n := bs.length; n < ret.length; n *= 2 {
+		CopyBits(ret, n, ret, 0, min(n, ret.length-n))
+	}
+	return ret
+}
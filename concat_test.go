@@ -0,0 +1,58 @@
+package bitstring
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcatSplit(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	sizes := []int{0, 1, 7, 63, 64, 65, 3, 130, 0, 5}
+	var (
+		parts []*Bitstring
+		want  string
+	)
+	for _, size := range sizes {
+		p := Random(size, rng)
+		parts = append(parts, p)
+		want = p.String() + want
+	}
+
+	bs := Concat(parts...)
+	assert.Equal(t, want, bs.String())
+
+	split := bs.Split(sizes...)
+	assert.Len(t, split, len(parts))
+	for i := range parts {
+		equalbits(t, split[i], parts[i])
+	}
+
+	// Remaining bits are returned in a last part.
+	split = bs.Split(1, 7)
+	assert.Len(t, split, 3)
+	assert.Equal(t, bs.Len()-8, split[2].Len())
+	equalbits(t, Concat(split...), bs)
+
+	assert.Equal(t, 0, Concat().Len())
+	assert.Empty(t, New(0).Split())
+	assert.Panics(t, func() { bs.Split(-1) })
+	assert.Panics(t, func() { bs.Split(bs.Len(), 1) })
+}
+
+func TestRepeat(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	for _, length := range []int{0, 1, 7, 64, 65, 130} {
+		for _, count := range []int{0, 1, 2, 3, 10, 17} {
+			bs := Random(length, rng)
+			want, _ := NewFromString(strings.Repeat(bs.String(), count))
+			equalbits(t, Repeat(bs, count), want)
+		}
+	}
+
+	assert.Panics(t, func() { Repeat(New(1), -1) })
+}
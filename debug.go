@@ -1,13 +1,87 @@
+//go:build bitstring_debug
 // +build bitstring_debug
 
 package bitstring
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
-// mustExist panics if i is not a valid bit index for bs, that is if i is
-// greater than bs.length.
+// mustExist panics if bs is nil or if i is not a valid bit index for bs, that
+// is if i is not in [0, bs.length).
 func (bs *Bitstring) mustExist(i int) {
-	if i >= bs.length {
-		panic(fmt.Sprintf("Bitstring: index %d is out of range [%d, %d]", i, 0, bs.length))
+	bs.mustNotNil()
+	if err := bs.checkIndex(i); err != nil {
+		panic(err.Error())
+	}
+}
+
+// mustExistRange panics if bs is nil or if the range [off, off+n) doesn't exist
+// in bs. Empty ranges are valid as long as off is in [0, bs.length].
+func (bs *Bitstring) mustExistRange(off, n int) {
+	bs.mustNotNil()
+	if err := bs.checkRange(off, n); err != nil {
+		panic(err.Error())
+	}
+}
+
+// mustHaveClearPadding panics if the bits of the last word of bs beyond its
+// length are not all zeroes. It's called after each mutation since most
+// methods rely on it, see clearPadding.
+func (bs *Bitstring) mustHaveClearPadding() {
+	bs.mustNotNil()
+	if len(bs.data) != nwords(bs.length) {
+		panic(fmt.Sprintf("bitstring: %d words for a length of %d", len(bs.data), bs.length))
+	}
+	if off := bitoffset(uint64(bs.length)); off != 0 {
+		if pad := bs.data[len(bs.data)-1] & himask(off); pad != 0 {
+			panic(fmt.Sprintf("bitstring: padding bits are not clear (%#x), length %d", pad, bs.length))
+		}
+	}
+}
+
+func (bs *Bitstring) mustNotNil() {
+	if bs == nil {
+		panic("bitstring: nil Bitstring")
+	}
+}
+
+// mustNotNegative panics if off or n is negative.
+func mustNotNegative(off, n int) {
+	if err := checkRange(off, n, math.MaxInt); err != nil {
+		panic(err.Error())
+	}
+}
+
+// mustHaveSameLength panics if x and y don't have the same length.
+func mustHaveSameLength(x, y *Bitstring) {
+	if err := checkSameLength(x, y); err != nil {
+		panic(err.Error())
+	}
+}
+
+// mustExist panics if i is not a valid bit index for v, that is if i is not in
+// [0, v.length), or if the range of v doesn't exist anymore in its parent.
+func (v View) mustExist(i int) {
+	v.mustExistParent()
+	if err := checkIndex(i, v.length); err != nil {
+		panic("View: " + err.Error())
+	}
+}
+
+// mustExistRange panics if the range [off, off+n) doesn't exist in v, or if
+// the range of v doesn't exist anymore in its parent.
+func (v View) mustExistRange(off, n int) {
+	v.mustExistParent()
+	if err := checkRange(off, n, v.length); err != nil {
+		panic("View: " + err.Error())
+	}
+}
+
+func (v View) mustExistParent() {
+	v.bs.mustNotNil()
+	if err := v.bs.checkRange(v.off, v.length); err != nil {
+		panic("View: parent has shrunk: " + err.Error())
 	}
 }
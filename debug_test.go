@@ -1,3 +1,4 @@
+//go:build bitstring_debug
 // +build bitstring_debug
 
 package bitstring
@@ -15,4 +16,121 @@ func TestSetBitDebug(t *testing.T) {
 		// length-1.
 		assert.Panics(t, func() { bs.ClearBit(1) })
 	})
+	t.Run("panics on negative index", func(t *testing.T) {
+		assert.Panics(t, func() { bs.SetBit(-1) })
+		assert.Panics(t, func() { bs.Bit(-64) })
+	})
+}
+
+func TestViewDebug(t *testing.T) {
+	v := New(100).Slice(10, 20)
+	t.Run("panics on index too high", func(t *testing.T) {
+		assert.Panics(t, func() { v.SetBit(20) })
+		assert.Panics(t, func() { v.Uint8(13) })
+		assert.Panics(t, func() { v.Slice(10, 11) })
+	})
+	t.Run("panics on negative index", func(t *testing.T) {
+		assert.Panics(t, func() { v.Bit(-1) })
+		assert.Panics(t, func() { v.SetRange(-1, 2) })
+	})
+	t.Run("panics if parent has shrunk", func(t *testing.T) {
+		bs := New(100)
+		v := bs.Slice(50, 20)
+		bs.Truncate(60)
+		assert.Panics(t, func() { v.Bit(0) })
+	})
+}
+
+func TestIntDebug(t *testing.T) {
+	bs := New(100)
+	assert.PanicsWithValue(t, "bitstring: negative offset: offset -1", func() { bs.Uint8(-1) })
+	assert.Panics(t, func() { bs.Uint64(37) })
+	assert.Panics(t, func() { bs.SetUint16(-8, 0) })
+	assert.Panics(t, func() { bs.SetUint32(69, 0) })
+	assert.Panics(t, func() { bs.Intn(-1, 3) })
+	assert.Panics(t, func() { bs.SetIntn(98, 3, 0) })
+	assert.NotPanics(t, func() { bs.SetUint64(36, 1) })
+	assert.NotPanics(t, func() { bs.Uintn(97, 3) })
+}
+
+func TestRangeDebug(t *testing.T) {
+	bs := New(100)
+	t.Run("panics on negative offset or length", func(t *testing.T) {
+		assert.Panics(t, func() { bs.SetRange(-1, 2) })
+		assert.PanicsWithValue(t, "bitstring: invalid width: negative length -1", func() { bs.ClearRange(10, -1) })
+		assert.Panics(t, func() { bs.FlipRange(-5, 5) })
+		assert.Panics(t, func() { bs.OnesCountRange(2, -2) })
+		assert.Panics(t, func() { bs.CopyRange(-1, 1) })
+		assert.Panics(t, func() { bs.DeleteRange(-1, 1) })
+		assert.Panics(t, func() { bs.InsertZeroes(-1, 1) })
+		assert.Panics(t, func() { bs.InsertZeroes(0, -1) })
+		assert.Panics(t, func() { EqualRange(bs, bs, -1, 1) })
+		assert.Panics(t, func() { CopyBits(bs, -1, bs, 0, 1) })
+	})
+	t.Run("panics on range too high", func(t *testing.T) {
+		assert.Panics(t, func() { bs.SetRange(99, 2) })
+		assert.Panics(t, func() { bs.InsertZeroes(101, 1) })
+		assert.Panics(t, func() { bs.InsertRange(101, New(1)) })
+		assert.Panics(t, func() { CopyBits(bs, 0, New(10), 5, 6) })
+	})
+	t.Run("panics on SwapRange with a shorter bitstring", func(t *testing.T) {
+		assert.PanicsWithValue(t, "bitstring: out of range: range [40, 60), length 50", func() {
+			SwapRange(bs, New(50), 40, 20)
+		})
+		assert.Panics(t, func() { SwapRange(New(50), bs, 40, 20) })
+	})
+	t.Run("panics on SwapRange with mismatched lengths", func(t *testing.T) {
+		assert.PanicsWithValue(t, "bitstring: length mismatch: 100 != 50", func() {
+			SwapRange(New(100), New(50), 0, 10)
+		})
+	})
+	t.Run("accepts empty ranges", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			bs.SetRange(100, 0)
+			bs.DeleteRange(0, 0)
+			bs.InsertZeroes(100, 0)
+			SwapRange(bs, New(100), 100, 0)
+		})
+	})
+}
+
+func TestGrayDebug(t *testing.T) {
+	bs := New(100)
+	assert.Panics(t, func() { bs.Grayn(0, 65) })
+	assert.Panics(t, func() { bs.Grayn(0, 0) })
+	assert.Panics(t, func() { bs.SetGrayn(0, 65, 0) })
+	assert.Panics(t, func() { bs.Gray16(-1) })
+	assert.Panics(t, func() { bs.SetGray32(80, 0) })
+}
+
+func TestNilDebug(t *testing.T) {
+	var bs *Bitstring
+	assert.PanicsWithValue(t, "bitstring: nil Bitstring", func() { bs.Bit(0) })
+	assert.PanicsWithValue(t, "bitstring: nil Bitstring", func() { bs.Uint8(0) })
+	assert.PanicsWithValue(t, "bitstring: nil Bitstring", func() { bs.SetRange(0, 1) })
+}
+
+func TestPaddingDebug(t *testing.T) {
+	bs := New(70)
+	bs.data[1] |= 1 << 6 // bit 70, past the end
+
+	assert.PanicsWithValue(t, "bitstring: padding bits are not clear (0x40), length 70", func() { bs.SetUint8(0, 1) })
+	assert.Panics(t, func() { bs.FlipRange(0, 1) })
+	assert.Panics(t, func() { bs.ToGray() })
+	assert.Panics(t, func() { New(70).Or(bs, New(70)) })
+	assert.Panics(t, func() { Copy(New(70), bs) })
+	assert.Panics(t, func() { bs.Grow(1) })
+
+	bs.clearPadding()
+	assert.NotPanics(t, func() {
+		bs.SetUintn(60, 10, 1023)
+		bs.FlipRange(0, 70)
+		bs.ToGray()
+		bs.FromGray()
+		bs.RotateLeft(3)
+		bs.ShiftRightArith(5)
+		bs.Reverse()
+		bs.AppendUintn(3, 2)
+		bs.Not(bs)
+	})
 }
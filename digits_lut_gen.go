@@ -0,0 +1,3 @@
+package bitstring
+
+//go:generate go run digits_lut_generate.go
@@ -0,0 +1,50 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+// This program generates the lookup table used to convert a byte into its 8
+// binary digits.
+
+func main() {
+	var sb strings.Builder
+
+	sb.WriteString("// Generated code; DO NOT EDIT.\n")
+	sb.WriteString("//\n")
+	sb.WriteString("// generated with: go run digits_lut_generate.go\n")
+	sb.WriteString("\n")
+	sb.WriteString("package bitstring\n")
+	sb.WriteString("\n")
+	sb.WriteString("// digitsLut maps a byte to its 8 ASCII binary digits, packed into a uint64\n")
+	sb.WriteString("// such that storing it in little endian writes the most significant digit\n")
+	sb.WriteString("// first.\n")
+	sb.WriteString("var digitsLut = [256]uint64 {\n")
+
+	for i := 0; i < 64; i++ {
+		for j := 0; j < 4; j++ {
+			v := i*4 + j
+			var digits uint64
+			for k := 0; k < 8; k++ {
+				digits |= uint64('0'+(v>>(7-k))&1) << (8 * k)
+			}
+			fmt.Fprintf(&sb, "%#016x, ", digits)
+		}
+		sb.WriteByte('\n')
+	}
+
+	sb.WriteString("}\n")
+	buf, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	os.WriteFile("digits_lut.go", buf, 0664)
+}
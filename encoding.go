@@ -0,0 +1,234 @@
+package bitstring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MaxDecodeLen is the maximum length, in bits, of a Bitstring decoded by
+// UnmarshalBinary, UnmarshalText, UnmarshalJSON or ReadFrom. Decoding a longer
+// Bitstring fails with an error before anything is allocated, so that
+// untrusted input can't force huge allocations. It defaults to 1<<32 bits
+// (512MiB), or math.MaxInt-63 on 32-bit platforms. Larger values are clamped
+// to math.MaxInt-63.
+var MaxDecodeLen = min(1<<32, maxLength)
+
+// binaryVersion is the version of the binary format produced by MarshalBinary
+// and WriteTo.
+const binaryVersion byte = 1
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. It's also
+// used by encoding/gob.
+//
+// The binary format is made of a version byte, the bitstring length in bits
+// encoded as an unsigned varint, and the underlying words in little endian.
+func (bs *Bitstring) MarshalBinary() ([]byte, error) {
+	return bs.AppendBinary(make([]byte, 0, 1+binary.MaxVarintLen64+8*len(bs.data)))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface. It appends
+// to b the binary form of bs, as returned by MarshalBinary, and returns the
+// extended slice. It never returns an error.
+func (bs *Bitstring) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, binaryVersion)
+	b = binary.AppendUvarint(b, uint64(bs.length))
+	for _, w := range bs.data {
+		b = binary.LittleEndian.AppendUint64(b, w)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It's
+// also used by encoding/gob.
+func (bs *Bitstring) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("Bitstring.UnmarshalBinary: no data")
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("Bitstring.UnmarshalBinary: unsupported version %d", data[0])
+	}
+
+	length, n := binary.Uvarint(data[1:])
+	if n <= 0 {
+		return errors.New("Bitstring.UnmarshalBinary: invalid length")
+	}
+	if err := checkDecodeLen(length); err != nil {
+		return fmt.Errorf("Bitstring.UnmarshalBinary: %v", err)
+	}
+
+	words := data[1+n:]
+	if len(words) != 8*nwords(int(length)) {
+		return fmt.Errorf("Bitstring.UnmarshalBinary: invalid data size for %d bits", length)
+	}
+
+	dec, err := decodeWords(int(length), words)
+	if err != nil {
+		return fmt.Errorf("Bitstring.UnmarshalBinary: %v", err)
+	}
+	*bs = *dec
+	return nil
+}
+
+// WriteTo implements the io.WriterTo interface. It writes bs to w in the
+// binary format of MarshalBinary.
+func (bs *Bitstring) WriteTo(w io.Writer) (int64, error) {
+	b, _ := bs.MarshalBinary()
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadFrom implements the io.ReaderFrom interface. It reads, from r, a
+// Bitstring in the binary format of MarshalBinary and stores it in bs. ReadFrom
+// stops reading right after the bitstring data.
+func (bs *Bitstring) ReadFrom(r io.Reader) (int64, error) {
+	bc := &byteCounter{r: r}
+
+	version, err := bc.ReadByte()
+	if err != nil {
+		return bc.n, err
+	}
+	if version != binaryVersion {
+		return bc.n, fmt.Errorf("Bitstring.ReadFrom: unsupported version %d", version)
+	}
+
+	length, err := binary.ReadUvarint(bc)
+	if err != nil {
+		return bc.n, unexpectedEOF(err)
+	}
+	if err := checkDecodeLen(length); err != nil {
+		return bc.n, fmt.Errorf("Bitstring.ReadFrom: %v", err)
+	}
+
+	// The length comes from r, don't trust it to preallocate the words: the
+	// buffer only grows as data actually arrives.
+	var words bytes.Buffer
+	n, err := io.CopyN(&words, r, 8*int64(nwords(int(length))))
+	bc.n += n
+	if err != nil {
+		return bc.n, unexpectedEOF(err)
+	}
+
+	dec, err := decodeWords(int(length), words.Bytes())
+	if err != nil {
+		return bc.n, fmt.Errorf("Bitstring.ReadFrom: %v", err)
+	}
+	*bs = *dec
+	return bc.n, nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The text form
+// is the string representation returned by String.
+func (bs *Bitstring) MarshalText() ([]byte, error) {
+	return bs.appendText(make([]byte, 0, bs.length)), nil
+}
+
+// AppendText implements the encoding.TextAppender interface. It appends to b
+// the string representation of bs, as returned by String, and returns the
+// extended slice. It never returns an error.
+func (bs *Bitstring) AppendText(b []byte) ([]byte, error) {
+	return bs.appendText(b), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// the string representation returned by String, see NewFromString.
+func (bs *Bitstring) UnmarshalText(text []byte) error {
+	if err := checkDecodeLen(uint64(len(text))); err != nil {
+		return fmt.Errorf("Bitstring.UnmarshalText: %v", err)
+	}
+
+	dec, err := NewFromString(string(text))
+	if err != nil {
+		return fmt.Errorf("Bitstring.UnmarshalText: %v", err)
+	}
+	*bs = *dec
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. A Bitstring is encoded
+// as a JSON string holding its string representation.
+func (bs *Bitstring) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, bs.length+2)
+	b = append(b, '"')
+	b = bs.appendText(b)
+	b = append(b, '"')
+	return b, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts a JSON
+// string holding the string representation of a Bitstring. By convention,
+// unmarshaling null is a no-op.
+func (bs *Bitstring) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	// 2 bytes for the quotes.
+	if err := checkDecodeLen(uint64(max(len(data)-2, 0))); err != nil {
+		return fmt.Errorf("Bitstring.UnmarshalJSON: %v", err)
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Bitstring.UnmarshalJSON: %v", err)
+	}
+
+	dec, err := NewFromString(s)
+	if err != nil {
+		return fmt.Errorf("Bitstring.UnmarshalJSON: %v", err)
+	}
+	*bs = *dec
+	return nil
+}
+
+// checkDecodeLen returns an error if a bitstring of the given length can't be
+// decoded.
+func checkDecodeLen(length uint64) error {
+	if max := min(MaxDecodeLen, maxLength); length > uint64(max) {
+		return fmt.Errorf("length %d exceeds MaxDecodeLen (%d)", length, max)
+	}
+	return nil
+}
+
+// decodeWords returns a Bitstring of the given length made of the little
+// endian words in b. invariant: len(b) == 8*nwords(length).
+func decodeWords(length int, b []byte) (*Bitstring, error) {
+	bs := New(length)
+	for i := range bs.data {
+		bs.data[i] = binary.LittleEndian.Uint64(b[8*i:])
+	}
+
+	// Reject data with extra bits set in the last word, since we rely on those
+	// being 0s.
+	if nused := bitoffset(uint64(length)); nused != 0 {
+		if bs.data[len(bs.data)-1]&^lomask(nused) != 0 {
+			return nil, errors.New("non-zero padding bits")
+		}
+	}
+	return bs, nil
+}
+
+// byteCounter is an io.ByteReader reading from r one byte at a time, and
+// counting the number of bytes read.
+type byteCounter struct {
+	r   io.Reader
+	n   int64
+	buf [1]byte
+}
+
+func (bc *byteCounter) ReadByte() (byte, error) {
+	n, err := io.ReadFull(bc.r, bc.buf[:])
+	bc.n += int64(n)
+	return bc.buf[0], err
+}
+
+// unexpectedEOF converts io.EOF into io.ErrUnexpectedEOF, since it's returned
+// when the input stops in the middle of a bitstring.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
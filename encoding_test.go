@@ -0,0 +1,192 @@
+package bitstring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	for _, length := range []int{0, 1, 7, 63, 64, 65, 130, 1029} {
+		t.Run(fmt.Sprintf("len=%d", length), func(t *testing.T) {
+			bs := Random(length, rng)
+
+			b, err := bs.MarshalBinary()
+			require.NoError(t, err)
+			var got Bitstring
+			require.NoError(t, got.UnmarshalBinary(b))
+			equalbits(t, &got, bs)
+
+			text, err := bs.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, bs.String(), string(text))
+			got = Bitstring{}
+			require.NoError(t, got.UnmarshalText(text))
+			equalbits(t, &got, bs)
+
+			prefix := []byte("prefix")
+			appended, err := bs.AppendBinary(prefix)
+			require.NoError(t, err)
+			assert.Equal(t, append(prefix, b...), appended)
+			appended, err = bs.AppendText(prefix)
+			require.NoError(t, err)
+			assert.Equal(t, append(prefix, text...), appended)
+
+			var buf bytes.Buffer
+			n, err := bs.WriteTo(&buf)
+			require.NoError(t, err)
+			assert.EqualValues(t, len(b), n)
+			buf.WriteString("trailing data")
+			got = Bitstring{}
+			n, err = got.ReadFrom(&buf)
+			require.NoError(t, err)
+			assert.EqualValues(t, len(b), n)
+			equalbits(t, &got, bs)
+			assert.Equal(t, "trailing data", buf.String())
+		})
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	type config struct {
+		Mask *Bitstring `json:"mask"`
+		Opt  *Bitstring `json:"opt"`
+	}
+
+	bs, _ := NewFromString("0010110")
+	b, err := json.Marshal(config{Mask: bs})
+	require.NoError(t, err)
+	assert.Equal(t, `{"mask":"0010110","opt":null}`, string(b))
+
+	var cfg config
+	require.NoError(t, json.Unmarshal(b, &cfg))
+	equalbits(t, cfg.Mask, bs)
+	assert.Nil(t, cfg.Opt)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"mask":"0012"}`), &cfg))
+	assert.Error(t, json.Unmarshal([]byte(`{"mask":12}`), &cfg))
+}
+
+func TestGob(t *testing.T) {
+	type record struct {
+		Name string
+		Bits *Bitstring
+	}
+
+	rng := rand.New(rand.NewSource(99))
+	want := record{Name: "x", Bits: Random(200, rng)}
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(want))
+
+	var got record
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&got))
+	assert.Equal(t, want.Name, got.Name)
+	equalbits(t, got.Bits, want.Bits)
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	valid, _ := New(70).MarshalBinary()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"bad version", append([]byte{2}, valid[1:]...)},
+		{"no length", valid[:1]},
+		{"truncated words", valid[:len(valid)-1]},
+		{"extra words", append(valid, 0, 0, 0, 0, 0, 0, 0, 0)},
+		// 70 bits, the last word has the bit 6 set, which is out of range.
+		{"padding bits", append(append([]byte{}, valid[:len(valid)-8]...), 0x40, 0, 0, 0, 0, 0, 0, 0)},
+		{"huge length", []byte{1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bs Bitstring
+			assert.Error(t, bs.UnmarshalBinary(tt.data))
+
+			_, err := bs.ReadFrom(bytes.NewReader(tt.data))
+			if tt.name != "extra words" {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	// Truncated input is reported as an unexpected EOF.
+	var bs Bitstring
+	_, err := bs.ReadFrom(bytes.NewReader(valid[:len(valid)-1]))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	// But an empty input is a regular EOF.
+	_, err = bs.ReadFrom(bytes.NewReader(nil))
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReadFromHugeLength(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		// math.MaxInt32 bits, panicked on 32-bit platforms.
+		{"MaxInt32", []byte{1, 0xff, 0xff, 0xff, 0xff, 0x07}},
+		// 1<<32 bits, used to allocate 512MiB upfront on 64-bit platforms.
+		{"1<<32", []byte{1, 0x80, 0x80, 0x80, 0x80, 0x10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			_, err := new(Bitstring).ReadFrom(io.MultiReader(bytes.NewReader(tt.data), bytes.NewReader(make([]byte, 1000))))
+			runtime.ReadMemStats(&after)
+
+			assert.Error(t, err)
+			assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20))
+		})
+	}
+
+	// A huge MaxDecodeLen can't make lengths overflow.
+	defer func(max int) { MaxDecodeLen = max }(MaxDecodeLen)
+	MaxDecodeLen = math.MaxInt
+	b := binary.AppendUvarint([]byte{1}, math.MaxUint64>>1)
+	assert.Error(t, new(Bitstring).UnmarshalBinary(b))
+	_, err := new(Bitstring).ReadFrom(bytes.NewReader(b))
+	assert.Error(t, err)
+}
+
+func TestMaxDecodeLen(t *testing.T) {
+	defer func(max int) { MaxDecodeLen = max }(MaxDecodeLen)
+	MaxDecodeLen = 100
+
+	ok, tooLong := New(100), New(101)
+
+	b, _ := ok.MarshalBinary()
+	assert.NoError(t, new(Bitstring).UnmarshalBinary(b))
+	_, err := new(Bitstring).ReadFrom(bytes.NewReader(b))
+	assert.NoError(t, err)
+	text, _ := ok.MarshalText()
+	assert.NoError(t, new(Bitstring).UnmarshalText(text))
+	js, _ := ok.MarshalJSON()
+	assert.NoError(t, new(Bitstring).UnmarshalJSON(js))
+
+	b, _ = tooLong.MarshalBinary()
+	assert.Error(t, new(Bitstring).UnmarshalBinary(b))
+	_, err = new(Bitstring).ReadFrom(bytes.NewReader(b))
+	assert.Error(t, err)
+	text, _ = tooLong.MarshalText()
+	assert.Error(t, new(Bitstring).UnmarshalText(text))
+	js, _ = tooLong.MarshalJSON()
+	assert.Error(t, new(Bitstring).UnmarshalJSON(js))
+}
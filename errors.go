@@ -0,0 +1,29 @@
+package bitstring
+
+import "errors"
+
+var (
+	// ErrOverflow is returned, wrapped, when a value doesn't fit in the
+	// number of bits it's stored into.
+	ErrOverflow = errors.New("bitstring: value overflows field")
+
+	// ErrInvalidBCD is returned, wrapped, when a binary-coded decimal digit
+	// is greater than 9.
+	ErrInvalidBCD = errors.New("bitstring: invalid BCD digit")
+
+	// ErrOutOfRange is returned, wrapped, by the Checked API when a bit index
+	// or a range of bits goes past the end of the bitstring.
+	ErrOutOfRange = errors.New("bitstring: out of range")
+
+	// ErrNegativeOffset is returned, wrapped, by the Checked API when a bit
+	// index or offset is negative.
+	ErrNegativeOffset = errors.New("bitstring: negative offset")
+
+	// ErrWidth is returned, wrapped, by the Checked API when the width of a
+	// field, or the length of a range, is not supported.
+	ErrWidth = errors.New("bitstring: invalid width")
+
+	// ErrLengthMismatch is returned, wrapped, by the Checked API when an
+	// operation involving 2 bitstrings requires them to have the same length.
+	ErrLengthMismatch = errors.New("bitstring: length mismatch")
+)
@@ -126,3 +126,44 @@ func ExampleBitstring_FlipRange() {
 	fmt.Println(bs)
 	// Output: 11001100
 }
+
+func ExampleBitstring_And() {
+	x, _ := NewFromString("1100")
+	y, _ := NewFromString("1010")
+
+	// Store the result in a new Bitstring.
+	z := new(Bitstring).And(x, y)
+	fmt.Println(z)
+
+	// Or perform the operation in-place.
+	x.And(x, y)
+	fmt.Println(x)
+	// Output: 1000
+	// 1000
+}
+
+func ExampleBitstring_Ones() {
+	bs, _ := NewFromString("10010110")
+
+	for i := range bs.Ones() {
+		fmt.Println(i)
+	}
+	// Output: 1
+	// 2
+	// 4
+	// 7
+}
+
+func ExampleConcat() {
+	lo, _ := NewFromString("0001")
+	hi, _ := NewFromString("111")
+
+	bs := Concat(lo, hi)
+	fmt.Println(bs)
+
+	// Split is the inverse of Concat.
+	parts := bs.Split(4)
+	fmt.Println(parts[0], parts[1])
+	// Output: 1110001
+	// 0001 111
+}
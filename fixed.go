@@ -0,0 +1,58 @@
+package bitstring
+
+import (
+	"fmt"
+	"math"
+)
+
+// Fixed interprets the intBits+fracBits bits at offset off as a fixed-point
+// number in big endian and returns its value. The number is made of intBits
+// integer bits followed by fracBits fractional bits. If signed is true, it's
+// in two's complement and the sign bit is counted in intBits, so that a Q1.15
+// number has 1 integer bit (the sign) and 15 fractional bits, 16 bits total.
+//
+// The result is exact as long as intBits+fracBits is not greater than 53.
+// Behavior is undefined if there aren't enough bits. Panics if
+// intBits+fracBits is not in [1, 64].
+func (bs *Bitstring) Fixed(off, intBits, fracBits int, signed bool) float64 {
+	n := intBits + fracBits
+	var raw float64
+	if signed {
+		raw = float64(bs.Intn(off, n))
+	} else {
+		raw = float64(bs.Uintn(off, n))
+	}
+	return math.Ldexp(raw, -fracBits)
+}
+
+// SetFixed sets the intBits+fracBits bits at offset off with val converted to
+// a fixed-point number, as described in Fixed. val is rounded to the nearest
+// multiple of 2^-fracBits, ties to even. If the rounded value can't be
+// represented in the fixed-point format, or if val is NaN, bs is left untouched
+// and an error wrapping ErrOverflow is returned. Behavior is undefined if there
+// aren't enough bits. Panics if intBits+fracBits is not in [1, 64].
+func (bs *Bitstring) SetFixed(off, intBits, fracBits int, signed bool, val float64) error {
+	n := intBits + fracBits
+	if n < 1 || n > 64 {
+		panic("SetFixed supports fixed-point numbers from 1 to 64 bits long")
+	}
+
+	raw := math.RoundToEven(math.Ldexp(val, fracBits))
+
+	// [lo, hi) range of raw values, exactly representable as float64 since
+	// they're powers of 2.
+	lo, hi := 0.0, math.Ldexp(1, n)
+	if signed {
+		lo, hi = -math.Ldexp(1, n-1), math.Ldexp(1, n-1)
+	}
+	if !(raw >= lo && raw < hi) {
+		return fmt.Errorf("%w: %v doesn't fit in Q%d.%d", ErrOverflow, val, intBits, fracBits)
+	}
+
+	if signed {
+		bs.SetIntn(off, n, int64(raw))
+	} else {
+		bs.SetUintn(off, n, uint64(raw))
+	}
+	return nil
+}
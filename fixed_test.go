@@ -0,0 +1,80 @@
+package bitstring
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixed(t *testing.T) {
+	tests := []struct {
+		intBits, fracBits int
+		signed            bool
+		val               float64
+		raw               uint64
+		back              float64 // value read back
+	}{
+		// Q1.15
+		{intBits: 1, fracBits: 15, signed: true, val: 0.5, raw: 0x4000, back: 0.5},
+		{intBits: 1, fracBits: 15, signed: true, val: -0.5, raw: 0xc000, back: -0.5},
+		{intBits: 1, fracBits: 15, signed: true, val: -1, raw: 0x8000, back: -1},
+		{intBits: 1, fracBits: 15, signed: true, val: 1 - 0x1p-15, raw: 0x7fff, back: 1 - 0x1p-15},
+		{intBits: 1, fracBits: 15, signed: true, val: 0.1, raw: 0x0ccd, back: 0x0ccdp-15},
+		// Q8.8
+		{intBits: 8, fracBits: 8, signed: true, val: 3.75, raw: 0x03c0, back: 3.75},
+		{intBits: 8, fracBits: 8, signed: true, val: -128, raw: 0x8000, back: -128},
+		{intBits: 8, fracBits: 8, signed: false, val: 255.99609375, raw: 0xffff, back: 255.99609375},
+		{intBits: 8, fracBits: 8, signed: false, val: 0x1.8p-8, raw: 0x0002, back: 0x1p-7}, // tie, rounds to even
+		{intBits: 8, fracBits: 8, signed: false, val: 0x1p-9, raw: 0x0000, back: 0},        // tie, rounds to even
+		// integers and odd widths
+		{intBits: 5, fracBits: 0, signed: true, val: -16, raw: 0x10, back: -16},
+		{intBits: 0, fracBits: 3, signed: false, val: 0.875, raw: 0x7, back: 0.875},
+		{intBits: 0, fracBits: 3, signed: true, val: -0.5, raw: 0x4, back: -0.5},
+		{intBits: 64, fracBits: 0, signed: true, val: -0x1p63, raw: 1 << 63, back: -0x1p63},
+		{intBits: 32, fracBits: 32, signed: false, val: 0x1p31 + 0x1p-21, raw: 1<<63 | 1<<11, back: 0x1p31 + 0x1p-21},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("Q%d.%d(%v)", tt.intBits, tt.fracBits, tt.val), func(t *testing.T) {
+			n := tt.intBits + tt.fracBits
+			bs := New(n + 70)
+			assert.NoError(t, bs.SetFixed(67, tt.intBits, tt.fracBits, tt.signed, tt.val))
+			assert.Equalf(t, tt.raw, bs.Uintn(67, n), "raw value")
+			assert.Equal(t, tt.back, bs.Fixed(67, tt.intBits, tt.fracBits, tt.signed))
+		})
+	}
+}
+
+func TestSetFixedErrors(t *testing.T) {
+	tests := []struct {
+		intBits, fracBits int
+		signed            bool
+		val               float64
+	}{
+		{intBits: 1, fracBits: 15, signed: true, val: 1},
+		{intBits: 1, fracBits: 15, signed: true, val: 1 - 0x1p-17}, // rounds to 1
+		{intBits: 1, fracBits: 15, signed: true, val: -1.0001},
+		{intBits: 8, fracBits: 8, signed: false, val: -0x1p-8},
+		{intBits: 8, fracBits: 8, signed: false, val: 256},
+		{intBits: 64, fracBits: 0, signed: true, val: 0x1p63},
+		{intBits: 64, fracBits: 0, signed: false, val: 0x1p64},
+		{intBits: 8, fracBits: 8, signed: true, val: math.NaN()},
+		{intBits: 8, fracBits: 8, signed: true, val: math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("Q%d.%d(%v)", tt.intBits, tt.fracBits, tt.val), func(t *testing.T) {
+			n := tt.intBits + tt.fracBits
+			bs := New(n)
+			bs.SetRange(0, n)
+			err := bs.SetFixed(0, tt.intBits, tt.fracBits, tt.signed, tt.val)
+			assert.ErrorIs(t, err, ErrOverflow)
+			assert.Equal(t, n, bs.OnesCount())
+		})
+	}
+
+	assert.Panics(t, func() { New(80).SetFixed(0, 60, 5, true, 0) })
+	assert.Panics(t, func() { New(80).SetFixed(0, 0, 0, true, 0) })
+}
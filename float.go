@@ -0,0 +1,89 @@
+package bitstring
+
+import "math"
+
+// Float32 interprets the 32 bits at offset off as an IEEE 754 binary32
+// floating-point number in big endian and returns its value. Behavior is
+// undefined if there aren't enough bits.
+func (bs *Bitstring) Float32(off int) float32 {
+	return math.Float32frombits(bs.Uint32(off))
+}
+
+// Float64 interprets the 64 bits at offset off as an IEEE 754 binary64
+// floating-point number in big endian and returns its value. Behavior is
+// undefined if there aren't enough bits.
+func (bs *Bitstring) Float64(off int) float64 {
+	return math.Float64frombits(bs.Uint64(off))
+}
+
+// SetFloat32 sets the 32 bits at offset off with the IEEE 754 binary32
+// representation of val, in big endian. Behavior is undefined if there aren't
+// enough bits.
+func (bs *Bitstring) SetFloat32(off int, val float32) {
+	bs.SetUint32(off, math.Float32bits(val))
+}
+
+// SetFloat64 sets the 64 bits at offset off with the IEEE 754 binary64
+// representation of val, in big endian. Behavior is undefined if there aren't
+// enough bits.
+func (bs *Bitstring) SetFloat64(off int, val float64) {
+	bs.SetUint64(off, math.Float64bits(val))
+}
+
+/* gray-coded floats */
+
+// In gray-coded float mode, floats are first mapped to unsigned integers
+// keeping their ordering: positive floats have their sign bit set while
+// negative floats have all their bits flipped. Then, these unsigned integers
+// are stored in gray code. As a result, adjacent representable floats, as
+// given by math.Nextafter, differ by exactly one bit (-0 and +0 being adjacent
+// too). The converse doesn't hold: flipping the most significant bit, for
+// instance, moves the value across the whole range and changes its sign.
+
+// GrayFloat32 interprets the 32 bits at offset off as a float32 stored with
+// SetGrayFloat32 and returns its value. Behavior is undefined if there aren't
+// enough bits.
+func (bs *Bitstring) GrayFloat32(off int) float32 {
+	key := bs.Gray32(off)
+	if key&(1<<31) != 0 {
+		return math.Float32frombits(key &^ (1 << 31))
+	}
+	return math.Float32frombits(^key)
+}
+
+// GrayFloat64 interprets the 64 bits at offset off as a float64 stored with
+// SetGrayFloat64 and returns its value. Behavior is undefined if there aren't
+// enough bits.
+func (bs *Bitstring) GrayFloat64(off int) float64 {
+	key := bs.Gray64(off)
+	if key&(1<<63) != 0 {
+		return math.Float64frombits(key &^ (1 << 63))
+	}
+	return math.Float64frombits(^key)
+}
+
+// SetGrayFloat32 sets the 32 bits at offset off with val, in the
+// order-preserving gray-coded float mode. Behavior is undefined if there
+// aren't enough bits.
+func (bs *Bitstring) SetGrayFloat32(off int, val float32) {
+	bits := math.Float32bits(val)
+	if bits&(1<<31) != 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 31
+	}
+	bs.SetGray32(off, bits)
+}
+
+// SetGrayFloat64 sets the 64 bits at offset off with val, in the
+// order-preserving gray-coded float mode. Behavior is undefined if there
+// aren't enough bits.
+func (bs *Bitstring) SetGrayFloat64(off int, val float64) {
+	bits := math.Float64bits(val)
+	if bits&(1<<63) != 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 63
+	}
+	bs.SetGray64(off, bits)
+}
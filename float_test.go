@@ -0,0 +1,79 @@
+package bitstring
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloat(t *testing.T) {
+	f32s := []float32{0, 1, -1, 0.1, -3.5e-40, math.MaxFloat32, math.SmallestNonzeroFloat32, float32(math.Inf(-1))}
+	f64s := []float64{0, 1, -1, 0.1, -3.5e-310, math.MaxFloat64, math.SmallestNonzeroFloat64, math.Inf(1)}
+
+	bs := New(150)
+	for _, off := range []int{0, 1, 31, 60, 63, 150 - 64} {
+		for _, f := range f32s {
+			bs.SetFloat32(off, f)
+			assert.Equal(t, math.Float32bits(f), bs.Uint32(off))
+			assert.Equal(t, f, bs.Float32(off))
+
+			bs.SetGrayFloat32(off, f)
+			assert.Equal(t, f, bs.GrayFloat32(off))
+		}
+		for _, f := range f64s {
+			bs.SetFloat64(off, f)
+			assert.Equal(t, math.Float64bits(f), bs.Uint64(off))
+			assert.Equal(t, f, bs.Float64(off))
+
+			bs.SetGrayFloat64(off, f)
+			assert.Equal(t, f, bs.GrayFloat64(off))
+		}
+	}
+
+	// NaN payloads are kept.
+	nan := math.Float64frombits(0x7ff8000000000123)
+	bs.SetGrayFloat64(3, nan)
+	assert.Equal(t, math.Float64bits(nan), math.Float64bits(bs.GrayFloat64(3)))
+
+	// Negative zero is kept.
+	bs.SetGrayFloat32(3, float32(math.Copysign(0, -1)))
+	assert.True(t, math.Signbit(float64(bs.GrayFloat32(3))))
+}
+
+func TestGrayFloatOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	// Consecutive floats differ by a single bit.
+	floats := []float64{-math.MaxFloat64, -1, -math.SmallestNonzeroFloat64, 0, math.SmallestNonzeroFloat64, 1, 1e300}
+	for i := 0; i < 100; i++ {
+		floats = append(floats, rng.NormFloat64()*1e6)
+	}
+	for _, f := range floats {
+		next := math.Nextafter(f, math.Inf(1))
+		a, b := New(64), New(64)
+		a.SetGrayFloat64(0, f)
+		b.SetGrayFloat64(0, next)
+		a.Xor(a, b)
+		assert.Equalf(t, 1, a.OnesCount(), "%v and %v", f, next)
+
+		f32 := float32(f)
+		if f32 == 0 || math.IsInf(float64(f32), 0) {
+			continue // Nextafter32 skips -0, or doesn't move
+		}
+		next32 := math.Nextafter32(f32, float32(math.Inf(1)))
+		a, b = New(32), New(32)
+		a.SetGrayFloat32(0, f32)
+		b.SetGrayFloat32(0, next32)
+		a.Xor(a, b)
+		assert.Equalf(t, 1, a.OnesCount(), "%v and %v", f32, next32)
+	}
+
+	// -0 and +0 are adjacent.
+	a, b := New(32), New(32)
+	a.SetGrayFloat32(0, float32(math.Copysign(0, -1)))
+	b.SetGrayFloat32(0, 0)
+	a.Xor(a, b)
+	assert.Equal(t, 1, a.OnesCount())
+}
@@ -0,0 +1,160 @@
+package bitstring
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+const (
+	lowerDigits = "0123456789abcdef"
+	upperDigits = "0123456789ABCDEF"
+)
+
+// Format implements the fmt.Formatter interface. It supports the following
+// verbs:
+//
+//	%b	base 2, one digit per bit
+//	%o	base 8
+//	%x	base 16, lower-case letters
+//	%X	base 16, upper-case letters
+//	%s %v	same as String (i.e %b without any flag)
+//
+// Digits are printed from the most significant to the least significant, like
+// numbers, so the leftmost digit may hold less than 3 or 4 bits in octal or
+// hexadecimal. The following flags are supported for %b, %o, %x and %X:
+//
+//	'#'	add a 0b, 0o, 0x or 0X prefix
+//	'+'	group digits and separate groups with '_'
+//	' '	group digits and separate groups with ' '
+//	'0'	pad with leading zeroes rather than spaces, after the prefix
+//
+// Groups are made of 4 digits, counting from the least significant digit. The
+// precision, if any, sets the number of digits per group, for example %+.8b
+// separates every 8 bits. Width and the '-' flag are supported for all verbs.
+func (bs *Bitstring) Format(f fmt.State, verb rune) {
+	if bs == nil {
+		io.WriteString(f, "<nil>")
+		return
+	}
+
+	var (
+		shift  int
+		digits = lowerDigits
+		prefix string
+	)
+	switch verb {
+	case 'v', 's':
+		// Flags such as '+' or '#' may be set when bs is printed as part of a
+		// struct, we only honor the width.
+		pad(f, bs.appendDigits(nil, 1, digits), 0, false)
+		return
+	case 'b':
+		shift, prefix = 1, "0b"
+	case 'o':
+		shift, prefix = 3, "0o"
+	case 'x':
+		shift, prefix = 4, "0x"
+	case 'X':
+		shift, prefix, digits = 4, "0X", upperDigits
+	default:
+		fmt.Fprintf(f, "%%!%c(*bitstring.Bitstring=%s)", verb, bs.String())
+		return
+	}
+
+	var buf []byte
+	if f.Flag('#') {
+		buf = append(buf, prefix...)
+	}
+	nprefix := len(buf)
+
+	switch {
+	case f.Flag('+'):
+		buf = group(buf, bs.appendDigits(nil, shift, digits), groupSize(f), '_')
+	case f.Flag(' '):
+		buf = group(buf, bs.appendDigits(nil, shift, digits), groupSize(f), ' ')
+	default:
+		buf = bs.appendDigits(buf, shift, digits)
+	}
+
+	pad(f, buf, nprefix, f.Flag('0'))
+}
+
+// appendDigits appends to dst the digits representing bs in base 1<<shift,
+// from the most significant to the least significant.
+func (bs *Bitstring) appendDigits(dst []byte, shift int, digits string) []byte {
+	if shift == 1 {
+		return bs.appendText(dst)
+	}
+
+	n := (bs.length + shift - 1) / shift
+
+	start := len(dst)
+	dst = slices.Grow(dst, n)[:start+n]
+	buf := dst[start:]
+	for i := 0; i < n; i++ {
+		off := i * shift
+		buf[n-1-i] = digits[bs.Uintn(off, min(shift, bs.length-off))]
+	}
+	return dst
+}
+
+// groupSize returns the number of digits per group.
+func groupSize(f fmt.State) int {
+	if prec, ok := f.Precision(); ok && prec > 0 {
+		return prec
+	}
+	return 4
+}
+
+// group appends to dst the digits in groups of size digits, counting from the
+// right, separated with sep.
+func group(dst, digits []byte, size int, sep byte) []byte {
+	if len(digits) == 0 {
+		return dst
+	}
+
+	first := len(digits) % size
+	if first == 0 {
+		first = size
+	}
+	dst = append(dst, digits[:first]...)
+	for i := first; i < len(digits); i += size {
+		dst = append(dst, sep)
+		dst = append(dst, digits[i:i+size]...)
+	}
+	return dst
+}
+
+// pad writes buf to f, padded to the width of f if any. If zero is true, and
+// the '-' flag is not set, buf is padded with zeroes inserted after its first
+// nprefix bytes, otherwise it's padded with spaces.
+func pad(f fmt.State, buf []byte, nprefix int, zero bool) {
+	width, ok := f.Width()
+	if !ok || width <= len(buf) {
+		f.Write(buf)
+		return
+	}
+
+	npad := width - len(buf)
+	switch {
+	case f.Flag('-'):
+		f.Write(buf)
+		writeRepeat(f, ' ', npad)
+	case zero:
+		f.Write(buf[:nprefix])
+		writeRepeat(f, '0', npad)
+		f.Write(buf[nprefix:])
+	default:
+		writeRepeat(f, ' ', npad)
+		f.Write(buf)
+	}
+}
+
+func writeRepeat(w io.Writer, c byte, n int) {
+	buf := make([]byte, n)
+	for i := range buf {
+		buf[i] = c
+	}
+	w.Write(buf)
+}
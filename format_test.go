@@ -0,0 +1,103 @@
+package bitstring
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	bs, _ := NewFromString("1101011111001") // 0x1af9, 0o15371
+	empty := New(0)
+
+	tests := []struct {
+		format string
+		bs     *Bitstring
+		want   string
+	}{
+		{"%v", bs, "1101011111001"},
+		{"%s", bs, "1101011111001"},
+		{"%b", bs, "1101011111001"},
+		{"%o", bs, "15371"},
+		{"%x", bs, "1af9"},
+		{"%X", bs, "1AF9"},
+
+		// prefix
+		{"%#b", bs, "0b1101011111001"},
+		{"%#o", bs, "0o15371"},
+		{"%#x", bs, "0x1af9"},
+		{"%#X", bs, "0X1AF9"},
+
+		// grouping
+		{"%+b", bs, "1_1010_1111_1001"},
+		{"% b", bs, "1 1010 1111 1001"},
+		{"%+.8b", bs, "11010_11111001"},
+		{"%+.2x", bs, "1a_f9"},
+		{"%+.1o", bs, "1_5_3_7_1"},
+		{"%#+b", bs, "0b1_1010_1111_1001"},
+
+		// width
+		{"%20b", bs, "       1101011111001"},
+		{"%-20b|", bs, "1101011111001       |"},
+		{"%020b", bs, "00000001101011111001"},
+		{"%#08x", bs, "0x001af9"},
+		{"%-#08x|", bs, "0x1af9  |"},
+		{"%2x", bs, "1af9"},
+		{"%16v", bs, "   1101011111001"},
+
+		// flags are ignored with %v and %s.
+		{"%+v", bs, "1101011111001"},
+		{"%#s", bs, "1101011111001"},
+
+		// empty bitstring
+		{"%v", empty, ""},
+		{"%#x", empty, "0x"},
+		{"%+b", empty, ""},
+		{"%4b", empty, "    "},
+
+		// bad verb
+		{"%d", bs, "%!d(*bitstring.Bitstring=1101011111001)"},
+		{"%v", (*Bitstring)(nil), "<nil>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.want, fmt.Sprintf(tt.format, tt.bs))
+		})
+	}
+}
+
+func TestFormatLong(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	for _, length := range []int{1, 3, 4, 63, 64, 65, 130, 1029} {
+		bs := Random(length, rng)
+		bi := bs.BigInt()
+
+		// Compare to big.Int formatting, after adding the leading zeroes.
+		for _, verb := range []string{"%b", "%o", "%x", "%X"} {
+			per := map[string]int{"%b": 1, "%o": 3, "%x": 4, "%X": 4}[verb]
+			ndigits := (length + per - 1) / per
+			want := fmt.Sprintf(verb, bi)
+			want = strings.Repeat("0", ndigits-len(want)) + want
+			assert.Equal(t, want, fmt.Sprintf(verb, bs), "%s of %d bits", verb, length)
+		}
+		assert.Equal(t, bs.String(), fmt.Sprint(bs))
+	}
+}
+
+func ExampleBitstring_Format() {
+	bs, _ := NewFromString("1101011111001")
+
+	fmt.Printf("%b\n", bs)
+	fmt.Printf("%#x\n", bs)
+	fmt.Printf("%+b\n", bs)
+	fmt.Printf("%#010X\n", bs)
+	// Output: 1101011111001
+	// 0x1af9
+	// 1_1010_1111_1001
+	// 0X00001AF9
+}
@@ -0,0 +1,109 @@
+package bitstring
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Integer is the set of integer types, including named types, supported by
+// the generic field accessors Get, GetN, Set and SetN.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// sizeof returns the size of T in bits.
+func sizeof[T Integer]() int {
+	var zero T
+	return 8 * int(unsafe.Sizeof(zero))
+}
+
+// signed reports whether T is a signed integer type.
+func signed[T Integer]() bool {
+	var zero T
+	return ^zero < 0
+}
+
+// Get interprets the bits at offset off of bs as a value of type T, in big
+// endian, and returns it. The number of bits read is the size of T, for
+// example Get[int16] reads 16 bits. Behavior is undefined if there aren't
+// enough bits.
+func Get[T Integer](bs *Bitstring, off int) T {
+	return GetN[T](bs, off, sizeof[T]())
+}
+
+// GetN interprets the n bits at offset off of bs as an n-bit integer of type T,
+// in big endian, and returns it. If T is a signed type, bit off+n-1 is the sign
+// bit and is extended to the upper bits of the result. Behavior is undefined if
+// there aren't enough bits. Panics if n is greater than the size of T.
+func GetN[T Integer](bs *Bitstring, off, n int) T {
+	mustFit[T](n)
+
+	if signed[T]() {
+		return T(bs.Intn(off, n))
+	}
+	return T(bs.Uintn(off, n))
+}
+
+// Set sets the bits at offset off of bs with val, in big endian. The number
+// of bits written is the size of T, for example Set[int16] writes 16 bits.
+// Behavior is undefined if there aren't enough bits.
+func Set[T Integer](bs *Bitstring, off int, val T) {
+	SetN(bs, off, sizeof[T](), val)
+}
+
+// SetN sets the n bits at offset off of bs with the n least significant bits
+// of val, in big endian. Behavior is undefined if there aren't enough bits.
+// Panics if n is greater than the size of T.
+func SetN[T Integer](bs *Bitstring, off, n int, val T) {
+	mustFit[T](n)
+
+	bs.SetUintn(off, n, uint64(val))
+}
+
+// GetChecked is like Get but returns an error if there aren't enough bits,
+// see Checked.
+func GetChecked[T Integer](bs *Bitstring, off int) (T, error) {
+	return GetNChecked[T](bs, off, sizeof[T]())
+}
+
+// GetNChecked is like GetN but returns an error if there aren't enough bits or
+// if n is not in [1, size of T], see Checked.
+func GetNChecked[T Integer](bs *Bitstring, off, n int) (T, error) {
+	if err := bs.checkField(off, n, sizeof[T]()); err != nil {
+		return 0, err
+	}
+	return GetN[T](bs, off, n), nil
+}
+
+// SetChecked is like Set but returns an error if there aren't enough bits, see
+// Checked.
+func SetChecked[T Integer](bs *Bitstring, off int, val T) error {
+	return SetNChecked(bs, off, sizeof[T](), val)
+}
+
+// SetNChecked is like SetN but returns an error if there aren't enough bits, if
+// n is not in [1, size of T] or if val can't be represented as an n-bit integer
+// of type T, see Checked. bs is left untouched on error.
+func SetNChecked[T Integer](bs *Bitstring, off, n int, val T) error {
+	if err := bs.checkField(off, n, sizeof[T]()); err != nil {
+		return err
+	}
+	var err error
+	if signed[T]() {
+		err = checkIntn(n, int64(val))
+	} else {
+		err = checkUintn(n, uint64(val))
+	}
+	if err != nil {
+		return err
+	}
+	SetN(bs, off, n, val)
+	return nil
+}
+
+func mustFit[T Integer](n int) {
+	if n > sizeof[T]() {
+		panic(fmt.Sprintf("%d bits don't fit in %T", n, *new(T)))
+	}
+}
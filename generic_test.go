@@ -0,0 +1,77 @@
+package bitstring
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	registerID uint16
+	celsius    int8
+)
+
+func TestGetSet(t *testing.T) {
+	bs, _ := NewFromString("11111111" + "0000000000000101" + "101")
+
+	assert.Equal(t, registerID(5), Get[registerID](bs, 3))
+	assert.Equal(t, celsius(-1), Get[celsius](bs, 19))
+	assert.Equal(t, uint8(0xff), Get[uint8](bs, 19))
+	assert.Equal(t, int64(-3), GetN[int64](bs, 0, 3))
+	assert.Equal(t, uint64(5), GetN[uint64](bs, 0, 3))
+	assert.Equal(t, int(-3), GetN[int](bs, 0, 3))
+
+	Set(bs, 3, registerID(0xabcd))
+	assert.Equal(t, uint16(0xabcd), bs.Uint16(3))
+	Set(bs, 19, celsius(-40))
+	assert.Equal(t, int8(-40), bs.Int8(19))
+	assert.Equal(t, "11011000"+"1010101111001101"+"101", bs.String())
+
+	SetN(bs, 0, 3, celsius(-2))
+	assert.Equal(t, celsius(-2), GetN[celsius](bs, 0, 3))
+	assert.Equal(t, "11011000"+"1010101111001101"+"110", bs.String())
+}
+
+func TestGetSetMatchMethods(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bs := Random(200, rng)
+
+	for i := 0; i < 50; i++ {
+		off := rng.Intn(200 - 64)
+
+		assert.Equal(t, bs.Uint8(off), Get[uint8](bs, off))
+		assert.Equal(t, bs.Uint16(off), Get[uint16](bs, off))
+		assert.Equal(t, bs.Uint32(off), Get[uint32](bs, off))
+		assert.Equal(t, bs.Uint64(off), Get[uint64](bs, off))
+		assert.Equal(t, bs.Int8(off), Get[int8](bs, off))
+		assert.Equal(t, bs.Int16(off), Get[int16](bs, off))
+		assert.Equal(t, bs.Int32(off), Get[int32](bs, off))
+		assert.Equal(t, bs.Int64(off), Get[int64](bs, off))
+
+		n := 1 + rng.Intn(64)
+		assert.Equal(t, bs.Uintn(off, n), GetN[uint64](bs, off, n))
+		assert.Equal(t, bs.Intn(off, n), GetN[int64](bs, off, n))
+
+		cpy := bs.Clone()
+		val := rng.Uint64()
+		Set(bs, off, int32(val))
+		cpy.SetInt32(off, int32(val))
+		equalbits(t, bs, cpy)
+
+		SetN(bs, off, n, val)
+		cpy.SetUintn(off, n, val)
+		equalbits(t, bs, cpy)
+	}
+
+	bs.SetInt64(3, math.MinInt64)
+	assert.Equal(t, int64(math.MinInt64), Get[int64](bs, 3))
+}
+
+func TestGetNPanics(t *testing.T) {
+	bs := New(100)
+	assert.PanicsWithValue(t, "9 bits don't fit in bitstring.celsius", func() { GetN[celsius](bs, 0, 9) })
+	assert.Panics(t, func() { SetN(bs, 0, 17, registerID(1)) })
+	assert.Panics(t, func() { GetN[uint64](bs, 0, 65) })
+}
@@ -63,3 +63,62 @@ func (bs *Bitstring) Grayn(off, n int) uint64 {
 	v ^= v >> 1
 	return v
 }
+
+// SetGray8 sets the 8 bits at offset off with the gray code of val, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (bs *Bitstring) SetGray8(off int, val uint8) { bs.SetUint8(off, val^val>>1) }
+
+// SetGray16 sets the 16 bits at offset off with the gray code of val, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (bs *Bitstring) SetGray16(off int, val uint16) { bs.SetUint16(off, val^val>>1) }
+
+// SetGray32 sets the 32 bits at offset off with the gray code of val, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (bs *Bitstring) SetGray32(off int, val uint32) { bs.SetUint32(off, val^val>>1) }
+
+// SetGray64 sets the 64 bits at offset off with the gray code of val, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (bs *Bitstring) SetGray64(off int, val uint64) { bs.SetUint64(off, val^val>>1) }
+
+// SetGrayn sets the n bits at offset off with the gray code of the n-bit
+// unsigned integer val, in big endian. Behavior is undefined if there aren't
+// enough bits. Panics if nbits is greater than 64.
+func (bs *Bitstring) SetGrayn(off, n int, val uint64) { bs.SetUintn(off, n, val^val>>1) }
+
+// ToGray converts bs, in-place, into its gray code. The whole bitstring is
+// considered as a single bs.Len()-bit unsigned integer, whatever its length.
+func (bs *Bitstring) ToGray() {
+	// g = b ^ b>>1, the bits shifted in from the next word being the low bits
+	// of that word, not yet converted.
+	for i := range bs.data {
+		w := bs.data[i]
+		next := uint64(0)
+		if i+1 < len(bs.data) {
+			next = bs.data[i+1]
+		}
+		bs.data[i] = w ^ (w>>1 | next<<63)
+	}
+	bs.mustHaveClearPadding()
+}
+
+// FromGray converts bs, in-place, from its gray code. This is the inverse of
+// ToGray.
+func (bs *Bitstring) FromGray() {
+	// Each bit is the xor of all the gray bits at the same or higher
+	// positions. Convert words from the most significant, carrying over the
+	// parity of all higher bits.
+	var parity uint64
+	for i := len(bs.data) - 1; i >= 0; i-- {
+		v := bs.data[i]
+		v ^= v >> 32
+		v ^= v >> 16
+		v ^= v >> 8
+		v ^= v >> 4
+		v ^= v >> 2
+		v ^= v >> 1
+		v ^= -parity // flips all bits if the parity of higher bits is odd
+		bs.data[i] = v
+		parity = v & 1
+	}
+	bs.mustHaveClearPadding()
+}
@@ -1,8 +1,13 @@
 package bitstring
 
 import (
+	"fmt"
 	"math"
+	"math/big"
+	"math/rand"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGray8(t *testing.T) {
@@ -112,3 +117,61 @@ func TestGrayn(t *testing.T) {
 		})
 	}
 }
+
+func TestSetGray(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bs := New(150)
+
+	for i := 0; i < 100; i++ {
+		off := rng.Intn(150 - 64)
+		val := rng.Uint64()
+
+		bs.SetGray8(off, uint8(val))
+		assert.Equal(t, uint8(val), bs.Gray8(off))
+		bs.SetGray16(off, uint16(val))
+		assert.Equal(t, uint16(val), bs.Gray16(off))
+		bs.SetGray32(off, uint32(val))
+		assert.Equal(t, uint32(val), bs.Gray32(off))
+		bs.SetGray64(off, val)
+		assert.Equal(t, val, bs.Gray64(off))
+
+		n := 1 + rng.Intn(64)
+		bs.SetGrayn(off, n, val&lomask(uint64(n)))
+		assert.Equal(t, val&lomask(uint64(n)), bs.Grayn(off, n))
+	}
+
+	// Consecutive values differ by a single bit.
+	bs = New(5)
+	for v := uint64(0); v < 31; v++ {
+		bs.SetGrayn(0, 5, v)
+		prev := bs.Clone()
+		bs.SetGrayn(0, 5, v+1)
+		prev.Xor(prev, bs)
+		assert.Equal(t, 1, prev.OnesCount())
+	}
+}
+
+func TestToFromGray(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	for _, length := range []int{0, 1, 5, 63, 64, 65, 128, 200, 1029} {
+		t.Run(fmt.Sprintf("len=%d", length), func(t *testing.T) {
+			bs := Random(length, rng)
+			bi := bs.BigInt()
+
+			gray := bs.Clone()
+			gray.ToGray()
+
+			// gray = bi ^ bi>>1
+			want := new(big.Int).Rsh(bi, 1)
+			want.Xor(want, bi)
+			assert.Equal(t, want.Text(2), gray.BigInt().Text(2))
+			if length <= 64 && length > 0 {
+				assert.Equal(t, bs.Uintn(0, length), gray.Grayn(0, length))
+			}
+
+			gray.FromGray()
+			equalbits(t, gray, bs)
+		})
+	}
+}
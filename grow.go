@@ -0,0 +1,97 @@
+package bitstring
+
+import "slices"
+
+// Cap returns the capacity of bs, that is the number of bits it can hold
+// without reallocating its underlying slice.
+func (bs *Bitstring) Cap() int {
+	return cap(bs.data) * 64
+}
+
+// Grow grows the capacity of bs, if necessary, to guarantee space for another
+// n bits. After Grow(n), at least n bits can be appended to bs without another
+// allocation. Panics if n is negative.
+func (bs *Bitstring) Grow(n int) {
+	if n < 0 {
+		panic("Bitstring.Grow: negative count")
+	}
+
+	need := nwords(bs.length + n)
+	if need > cap(bs.data) {
+		bs.data = slices.Grow(bs.data, need-len(bs.data))
+	}
+	bs.mustHaveClearPadding()
+}
+
+// Resize changes the length of bs to length bits. If length is greater than
+// bs.Len(), the new bits are set to 0, and the underlying slice grows as with
+// append. If length is less than bs.Len(), Resize is equivalent to
+// Truncate(length). Panics if length is negative.
+func (bs *Bitstring) Resize(length int) {
+	if length < 0 {
+		panic("Bitstring.Resize: negative length")
+	}
+	if length <= bs.length {
+		bs.Truncate(length)
+		return
+	}
+
+	bs.Grow(length - bs.length)
+
+	// Words past the current length may hold stale bits left by a previous
+	// Truncate, so we clear them. Extra bits of the current last word are
+	// always 0s.
+	old := len(bs.data)
+	bs.data = bs.data[:nwords(length)]
+	clear(bs.data[old:])
+	bs.length = length
+	bs.mustHaveClearPadding()
+}
+
+// Truncate discards all but the first length bits of bs, that is it only keeps
+// the bits in the [0, length) range. The capacity of bs is left unchanged.
+// Panics if length is negative or greater than bs.Len().
+func (bs *Bitstring) Truncate(length int) {
+	if length < 0 || length > bs.length {
+		panic("Bitstring.Truncate: length out of range")
+	}
+
+	bs.data = bs.data[:nwords(length)]
+	bs.length = length
+	bs.clearPadding()
+	bs.mustHaveClearPadding()
+}
+
+// AppendBit appends a bit to bs, growing it by one bit. The new bit is set if
+// bit is true, cleared otherwise.
+func (bs *Bitstring) AppendBit(bit bool) {
+	bs.Resize(bs.length + 1)
+	if bit {
+		bs.SetBit(bs.length - 1)
+	}
+	bs.mustHaveClearPadding()
+}
+
+// AppendUintn appends the n-bit unsigned integer val to bs, growing it by n
+// bits. Bits of val above n are ignored. Panics if n is greater than 64.
+func (bs *Bitstring) AppendUintn(val uint64, n int) {
+	if n > 64 || n < 1 {
+		panic("AppendUintn supports unsigned integers from 1 to 64 bits long")
+	}
+
+	off := bs.length
+	bs.Resize(off + n)
+	bs.SetUintn(off, n, val)
+	bs.mustHaveClearPadding()
+}
+
+// AppendBitstring appends all the bits of other to bs, growing it by
+// other.Len() bits. other may be bs.
+func (bs *Bitstring) AppendBitstring(other *Bitstring) {
+	n, off := other.length, bs.length
+	bs.Resize(off + n)
+
+	// If other is bs, the source and destination ranges do not overlap.
+	CopyBits(bs, off, other, 0, n)
+	bs.mustHaveClearPadding()
+}
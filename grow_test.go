@@ -0,0 +1,111 @@
+package bitstring
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrow(t *testing.T) {
+	var bs Bitstring
+	assert.Equal(t, 0, bs.Cap())
+
+	bs.Grow(100)
+	assert.Equal(t, 0, bs.Len())
+	assert.GreaterOrEqual(t, bs.Cap(), 100)
+
+	// Appending within the capacity doesn't reallocate.
+	data := bs.Data()
+	for i := 0; i < 100; i++ {
+		bs.AppendBit(true)
+	}
+	assert.Equal(t, 100, bs.Len())
+	assert.Equal(t, &data[:1][0], &bs.Data()[0])
+	assert.Equal(t, strings.Repeat("1", 100), bs.String())
+
+	assert.Panics(t, func() { bs.Grow(-1) })
+}
+
+func TestResizeTruncate(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bs := Random(300, rng)
+	s := bs.String()
+
+	bs.Truncate(130)
+	want, _ := NewFromString(s[300-130:])
+	equalbits(t, bs, want)
+
+	// The truncated bits must not reappear when growing again.
+	bs.Resize(300)
+	want, _ = NewFromString(strings.Repeat("0", 170) + s[300-130:])
+	equalbits(t, bs, want)
+
+	bs.Resize(65)
+	want, _ = NewFromString(s[300-65:])
+	equalbits(t, bs, want)
+
+	bs.Resize(0)
+	assert.Equal(t, 0, bs.Len())
+	assert.Equal(t, 0, bs.OnesCount())
+
+	bs.Resize(1000)
+	equalbits(t, bs, New(1000))
+
+	assert.Panics(t, func() { bs.Resize(-1) })
+	assert.Panics(t, func() { bs.Truncate(-1) })
+	assert.Panics(t, func() { bs.Truncate(1001) })
+}
+
+func TestAppend(t *testing.T) {
+	var (
+		bs   Bitstring
+		want string
+	)
+
+	for i := 0; i < 70; i++ {
+		bs.AppendBit(i%3 == 0)
+		if i%3 == 0 {
+			want = "1" + want
+		} else {
+			want = "0" + want
+		}
+	}
+	assert.Equal(t, want, bs.String())
+
+	bs.AppendUintn(0xffffffffffffffff, 3)
+	want = "111" + want
+	assert.Equal(t, want, bs.String())
+
+	bs.AppendUintn(0x8000000000000001, 64)
+	want = "1" + strings.Repeat("0", 62) + "1" + want
+	assert.Equal(t, want, bs.String())
+
+	bs.AppendUintn(0, 60)
+	want = strings.Repeat("0", 60) + want
+	assert.Equal(t, want, bs.String())
+
+	assert.Panics(t, func() { bs.AppendUintn(0, 65) })
+	assert.Panics(t, func() { bs.AppendUintn(0, 0) })
+}
+
+func TestAppendBitstring(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	for _, l1 := range []int{0, 1, 7, 63, 64, 65, 200} {
+		for _, l2 := range []int{0, 1, 7, 63, 64, 65, 200} {
+			x, y := Random(l1, rng), Random(l2, rng)
+			sx, sy := x.String(), y.String()
+
+			x.AppendBitstring(y)
+			want, _ := NewFromString(sy + sx)
+			equalbits(t, x, want)
+			assert.Equal(t, sy, y.String())
+
+			// Append to itself.
+			y.AppendBitstring(y)
+			want, _ = NewFromString(sy + sy)
+			equalbits(t, y, want)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package bitstring
 
+import "fmt"
+
 /* unsigned integer get */
 
 // Uint8 interprets the 8 bits at offset off as an uint8 in big endian and
 // returns its value. Behavior is undefined if there aren't enough bits.
 func (bs *Bitstring) Uint8(off int) uint8 {
-	bs.mustExist(off + 7)
+	bs.mustExistRange(off, 8)
 
 	return uint8(bs.uint(uint64(off), 7))
 }
@@ -13,7 +15,7 @@ func (bs *Bitstring) Uint8(off int) uint8 {
 // Uint16 interprets the 16 bits at offset off as an uint16 in big endian and
 // returns its value. Behavior is undefined if there aren't enough bits.
 func (bs *Bitstring) Uint16(off int) uint16 {
-	bs.mustExist(off + 15)
+	bs.mustExistRange(off, 16)
 
 	return uint16(bs.uint(uint64(off), 15))
 }
@@ -21,7 +23,7 @@ func (bs *Bitstring) Uint16(off int) uint16 {
 // Uint32 interprets the 32 bits at offset off as an uint32 in big endian and
 // returns its value. Behavior is undefined if there aren't enough bits.
 func (bs *Bitstring) Uint32(off int) uint32 {
-	bs.mustExist(off + 31)
+	bs.mustExistRange(off, 32)
 
 	return uint32(bs.uint(uint64(off), 31))
 }
@@ -29,7 +31,7 @@ func (bs *Bitstring) Uint32(off int) uint32 {
 // Uint64 interprets the 64 bits at offset off as an uint64 in big endian and
 // returns its value. Behavior is undefined if there aren't enough bits.
 func (bs *Bitstring) Uint64(off int) uint64 {
-	bs.mustExist(off + 63)
+	bs.mustExistRange(off, 64)
 
 	if off&((1<<6)-1) == 0 {
 		// Fast path: off is a multiple of 64.
@@ -51,7 +53,7 @@ func (bs *Bitstring) Uintn(off, n int) uint64 {
 	if n > 64 || n < 1 {
 		panic("Uintn supports unsigned integers from 1 to 64 bits long")
 	}
-	bs.mustExist(off + n - 1)
+	bs.mustExistRange(off, n)
 
 	i, nbits := uint64(off), uint64(n)
 	j := wordoffset(i)
@@ -80,7 +82,7 @@ func (bs *Bitstring) uint(off, n uint64) uint64 {
 // SetUint8 sets the 8 bits at offset off with the given int8 value, in big
 // endian. Behavior is undefined if there aren't enough bits.
 func (bs *Bitstring) SetUint8(off int, val uint8) {
-	bs.mustExist(off + 7)
+	bs.mustExistRange(off, 8)
 
 	i := uint64(off)
 	lobit := bitoffset(i)
@@ -92,6 +94,7 @@ func (bs *Bitstring) SetUint8(off int, val uint8) {
 		neww := uint64(val) << lobit
 		msk := mask(lobit, lobit+8)
 		bs.data[j] = transferbits(bs.data[j], neww, msk)
+		bs.mustHaveClearPadding()
 		return
 	}
 	// Transfer bits to low word.
@@ -99,12 +102,13 @@ func (bs *Bitstring) SetUint8(off int, val uint8) {
 	// Transfer bits to high word.
 	lon := 64 - lobit
 	bs.data[k] = transferbits(bs.data[k], uint64(val)>>lon, lomask(8-lon))
+	bs.mustHaveClearPadding()
 }
 
 // SetUint16 sets the 8 bits at offset off with the given int8 value, in big
 // endian. Behavior is undefined if there aren't enough bits.
 func (bs *Bitstring) SetUint16(off int, val uint16) {
-	bs.mustExist(off + 15)
+	bs.mustExistRange(off, 16)
 
 	i := uint64(off)
 	lobit := bitoffset(i)
@@ -115,6 +119,7 @@ func (bs *Bitstring) SetUint16(off int, val uint16) {
 		neww := uint64(val) << lobit
 		msk := mask(lobit, lobit+16)
 		bs.data[j] = transferbits(bs.data[j], neww, msk)
+		bs.mustHaveClearPadding()
 		return
 	}
 	// Transfer bits to low word.
@@ -122,12 +127,13 @@ func (bs *Bitstring) SetUint16(off int, val uint16) {
 	// Transfer bits to high word.
 	lon := 64 - lobit
 	bs.data[k] = transferbits(bs.data[k], uint64(val)>>lon, lomask(16-lon))
+	bs.mustHaveClearPadding()
 }
 
 // SetUint32 sets the 8 bits at offset off with the given int8 value, in big
 // endian. Behavior is undefined if there aren't enough bits.
 func (bs *Bitstring) SetUint32(off int, val uint32) {
-	bs.mustExist(off + 31)
+	bs.mustExistRange(off, 32)
 
 	i := uint64(off)
 	lobit := bitoffset(i)
@@ -138,6 +144,7 @@ func (bs *Bitstring) SetUint32(off int, val uint32) {
 		neww := uint64(val) << lobit
 		msk := mask(lobit, lobit+32)
 		bs.data[j] = transferbits(bs.data[j], neww, msk)
+		bs.mustHaveClearPadding()
 		return
 	}
 	// Transfer bits to low word.
@@ -145,12 +152,13 @@ func (bs *Bitstring) SetUint32(off int, val uint32) {
 	// Transfer bits to high word.
 	lon := 64 - lobit
 	bs.data[k] = transferbits(bs.data[k], uint64(val)>>lon, lomask(32-lon))
+	bs.mustHaveClearPadding()
 }
 
 // SetUint64 sets the 8 bits at offset off with the given int8 value, in big
 // endian. Behavior is undefined if there aren't enough bits.
 func (bs *Bitstring) SetUint64(off int, val uint64) {
-	bs.mustExist(off + 63)
+	bs.mustExistRange(off, 64)
 
 	i := uint64(off)
 	lobit := bitoffset(i)
@@ -159,6 +167,7 @@ func (bs *Bitstring) SetUint64(off int, val uint64) {
 	if off&((1<<6)-1) == 0 {
 		// Fast path: off is a multiple of 64.
 		bs.data[off>>6] = val
+		bs.mustHaveClearPadding()
 		return
 	}
 
@@ -168,6 +177,7 @@ func (bs *Bitstring) SetUint64(off int, val uint64) {
 	lon := (64 - lobit)
 	k := wordoffset(i + 63)
 	bs.data[k] = transferbits(bs.data[k], uint64(val)>>lon, lomask(64-lon))
+	bs.mustHaveClearPadding()
 }
 
 // SetUintn sets the n bits at offset off with the given n-bit unsigned integer in
@@ -177,7 +187,7 @@ func (bs *Bitstring) SetUintn(off, n int, val uint64) {
 	if n > 64 || n < 1 {
 		panic("SetUintn supports unsigned integers from 1 to 64 bits long")
 	}
-	bs.mustExist(off + n - 1)
+	bs.mustExistRange(off, n)
 
 	i, nbits := uint64(off), uint64(n)
 	lobit := bitoffset(i)
@@ -187,16 +197,18 @@ func (bs *Bitstring) SetUintn(off, n int, val uint64) {
 		// Fast path: value doesn't cross uint64 boundaries.
 		x := (val & lomask(nbits)) << lobit
 		bs.data[j] = transferbits(bs.data[j], x, mask(lobit, lobit+nbits))
+		bs.mustHaveClearPadding()
 		return
 	}
 
 	// First and last bits are on different words.
 	// Transfer bits to low word.
 	lon := 64 - lobit // how many bits of n we transfer to loword
-	bs.data[j] = transferbits(bs.data[j], val<<lobit, himask(lon))
+	bs.data[j] = transferbits(bs.data[j], val<<lobit, himask(lobit))
 
 	// Transfer bits to high word.
 	bs.data[k] = transferbits(bs.data[k], val>>lon, lomask(nbits-lon))
+	bs.mustHaveClearPadding()
 }
 
 /* signed get */
@@ -218,9 +230,13 @@ func (bs *Bitstring) Int32(off int) int32 { return int32(bs.Uint32(off)) }
 func (bs *Bitstring) Int64(off int) int64 { return int64(bs.Uint64(off)) }
 
 // Intn interprets the n bits at offset off as an n-bit signed integer in big
-// endian and returns its value. Behavior is undefined if there aren't enough
-// bits. Panics if nbits is greater than 64.
-func (bs *Bitstring) Intn(off, n int) int64 { return int64(bs.Uintn(off, n)) }
+// endian and returns its value, that is bit off+n-1 is the sign bit and is
+// extended to the upper bits of the returned int64. Behavior is undefined if
+// there aren't enough bits. Panics if nbits is greater than 64.
+func (bs *Bitstring) Intn(off, n int) int64 {
+	s := uint(64 - n)
+	return int64(bs.Uintn(off, n)<<s) >> s
+}
 
 /* signed integer set */
 
@@ -244,3 +260,28 @@ func (bs *Bitstring) SetInt64(off int, val int64) { bs.SetUint64(off, uint64(val
 // big endian. Behavior is undefined if there aren't enough bits. Panics if
 // nbits is greater than 64.
 func (bs *Bitstring) SetIntn(off, n int, val int64) { bs.SetUintn(off, n, uint64(val)) }
+
+// SetIntnChecked is like SetIntn but first checks that val can be represented
+// as an n-bit signed integer, that is -1<<(n-1) <= val < 1<<(n-1). If it
+// can't, bs is left untouched and an error wrapping ErrOverflow is returned.
+// Panics if nbits is greater than 64.
+func (bs *Bitstring) SetIntnChecked(off, n int, val int64) error {
+	if err := checkIntn(n, val); err != nil {
+		return err
+	}
+	bs.SetIntn(off, n, val)
+	return nil
+}
+
+// checkIntn returns an error wrapping ErrOverflow if val can't be represented
+// as an n-bit signed integer.
+func checkIntn(n int, val int64) error {
+	if n < 1 || n >= 64 {
+		// n-bit integers with n > 64 are rejected by SetUintn.
+		return nil
+	}
+	if lim := int64(1) << (n - 1); val < -lim || val >= lim {
+		return fmt.Errorf("%w: %d doesn't fit in %d bits", ErrOverflow, val, n)
+	}
+	return nil
+}
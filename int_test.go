@@ -1,7 +1,9 @@
 package bitstring
 
 import (
+	"fmt"
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -134,23 +136,27 @@ func TestNbits(t *testing.T) {
 		str    string
 		n, off int
 		want   uint64
+		wanti  int64 // want, sign-extended
 	}{
 		// Value lies on a single uint64
-		{str: "10", n: 1, off: 0, want: 0},
-		{str: "111", n: 1, off: 0, want: 1},
-		{str: "101", n: 1, off: 1, want: 0},
-		{str: "010", n: 1, off: 1, want: 1},
-		{str: "100", n: 2, off: 0, want: 0},
-		{str: "1101", n: 2, off: 1, want: 2},
-		{str: "10100000000000000000000000000000", n: 3, off: 29, want: 5},
-		{str: "10000000000000000000000000000000", n: 1, off: 31, want: 1},
-		{str: "1111111111111111111111111111111111111111111111111111111111111111", n: 3, off: 31, want: 7},
-		{str: "1111111111111111111111111111111111111111111111111111111111111111", n: 3, off: 30, want: 7},
-		{str: "0000000000000000000000000000001010000000000000000000000000000000", n: 3, off: 31, want: 5},
-		{str: "0000000000000000000000000000000101000000000000000000000000000000", n: 3, off: 30, want: 5},
+		{str: "10", n: 1, off: 0, want: 0, wanti: 0},
+		{str: "111", n: 1, off: 0, want: 1, wanti: -1},
+		{str: "101", n: 1, off: 1, want: 0, wanti: 0},
+		{str: "010", n: 1, off: 1, want: 1, wanti: -1},
+		{str: "100", n: 2, off: 0, want: 0, wanti: 0},
+		{str: "1101", n: 2, off: 1, want: 2, wanti: -2},
+		{str: "011", n: 3, off: 0, want: 3, wanti: 3},
+		{str: "11111", n: 5, off: 0, want: 31, wanti: -1},
+		{str: "01111", n: 5, off: 0, want: 15, wanti: 15},
+		{str: "10100000000000000000000000000000", n: 3, off: 29, want: 5, wanti: -3},
+		{str: "10000000000000000000000000000000", n: 1, off: 31, want: 1, wanti: -1},
+		{str: "1111111111111111111111111111111111111111111111111111111111111111", n: 3, off: 31, want: 7, wanti: -1},
+		{str: "1111111111111111111111111111111111111111111111111111111111111111", n: 3, off: 30, want: 7, wanti: -1},
+		{str: "0000000000000000000000000000001010000000000000000000000000000000", n: 3, off: 31, want: 5, wanti: -3},
+		{str: "0000000000000000000000000000000101000000000000000000000000000000", n: 3, off: 30, want: 5, wanti: -3},
 		// Value lies across 2 different uint64
-		{str: "000000000000000000000000000000101000000000000000000000000000000000000000000000000000000000000000", n: 3, off: 63, want: 5},
-		{str: "000000000000000000000000000000010100000000000000000000000000000000000000000000000000000000000000", n: 3, off: 62, want: 5},
+		{str: "000000000000000000000000000000101000000000000000000000000000000000000000000000000000000000000000", n: 3, off: 63, want: 5, wanti: -3},
+		{str: "000000000000000000000000000000010100000000000000000000000000000000000000000000000000000000000000", n: 3, off: 62, want: 5, wanti: -3},
 	}
 	for _, tt := range tests {
 		bs, _ := NewFromString(tt.str)
@@ -159,8 +165,66 @@ func TestNbits(t *testing.T) {
 			sprintubits(uint64(un), tt.n), sprintubits(uint64(tt.want), tt.n))
 
 		in := bs.Intn(tt.off, tt.n)
-		assert.Equalf(t, int64(tt.want), in, "got %s want %s",
-			sprintubits(uint64(in), tt.n), sprintubits(uint64(tt.want), tt.n))
+		assert.Equalf(t, tt.wanti, in, "got %d want %d", in, tt.wanti)
+	}
+}
+
+func TestIntnRoundTrip(t *testing.T) {
+	bs := New(130)
+	for _, n := range []int{1, 2, 5, 13, 32, 63, 64} {
+		for _, off := range []int{0, 60, 130 - n} {
+			lo, hi := int64(-1)<<(n-1), int64(1)<<(n-1)-1
+			if n == 64 {
+				lo, hi = math.MinInt64, math.MaxInt64
+			}
+			for _, val := range []int64{lo, lo + 1, -1, 0, 1, hi - 1, hi} {
+				if val < lo || val > hi {
+					continue // 1-bit integers are either -1 or 0
+				}
+				bs.SetIntn(off, n, val)
+				assert.Equalf(t, val, bs.Intn(off, n), "n=%d off=%d", n, off)
+			}
+		}
+	}
+}
+
+func TestSetIntnChecked(t *testing.T) {
+	tests := []struct {
+		n   int
+		val int64
+		ok  bool
+	}{
+		{n: 1, val: 0, ok: true},
+		{n: 1, val: -1, ok: true},
+		{n: 1, val: 1, ok: false},
+		{n: 5, val: 15, ok: true},
+		{n: 5, val: -16, ok: true},
+		{n: 5, val: 16, ok: false},
+		{n: 5, val: -17, ok: false},
+		{n: 63, val: math.MaxInt64 >> 1, ok: true},
+		{n: 63, val: math.MaxInt64, ok: false},
+		{n: 63, val: math.MinInt64, ok: false},
+		{n: 64, val: math.MaxInt64, ok: true},
+		{n: 64, val: math.MinInt64, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("n=%d,val=%d", tt.n, tt.val), func(t *testing.T) {
+			bs, _ := NewFromString(strings.Repeat("1", 80))
+			v := bs.Slice(3, 70)
+
+			err := bs.SetIntnChecked(7, tt.n, tt.val)
+			verr := v.SetIntnChecked(4, tt.n, tt.val)
+			if !tt.ok {
+				assert.ErrorIs(t, err, ErrOverflow)
+				assert.ErrorIs(t, verr, ErrOverflow)
+				// bs is left untouched.
+				assert.Equal(t, 80, bs.OnesCount())
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, verr)
+			assert.Equal(t, tt.val, bs.Intn(7, tt.n))
+		})
 	}
 }
 
@@ -501,6 +565,11 @@ func TestSetNbits(t *testing.T) {
 			str:  "000000000000000000001101000011010011000001010011010101010101000100101000111101010100000000000000000000000000000000000",
 			want: "000000000000000000100111001111101111101011011100011110111000111111110011110101111100000000000000000000000000000000000",
 		},
+		{
+			n: 20, val: 0, off: 50,
+			str:  "11111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111",
+			want: "11111111111111111111111111111111111111111111111111111111110000000000000000000011111111111111111111111111111111111111111111111111",
+		},
 	}
 
 	for _, tt := range tests {
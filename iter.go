@@ -0,0 +1,169 @@
+package bitstring
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// NextSet returns the index of the first set bit at or after index i, and
+// true, or 0 and false if there's no such bit. The search starts at index 0 if
+// i is negative.
+func (bs *Bitstring) NextSet(i int) (int, bool) {
+	if i < 0 {
+		i = 0
+	}
+	if i >= bs.length {
+		return 0, false
+	}
+
+	w := int(wordoffset(uint64(i)))
+	word := bs.data[w] & himask(bitoffset(uint64(i)))
+	for {
+		// The extra bits of the last word are always 0s so we never report a
+		// bit past the bitstring length.
+		if word != 0 {
+			return w*64 + bits.TrailingZeros64(word), true
+		}
+		w++
+		if w == len(bs.data) {
+			return 0, false
+		}
+		word = bs.data[w]
+	}
+}
+
+// NextClear returns the index of the first cleared bit at or after index i,
+// and true, or 0 and false if there's no such bit. The search starts at index
+// 0 if i is negative.
+func (bs *Bitstring) NextClear(i int) (int, bool) {
+	if i < 0 {
+		i = 0
+	}
+	if i >= bs.length {
+		return 0, false
+	}
+
+	w := int(wordoffset(uint64(i)))
+	word := ^bs.data[w] & himask(bitoffset(uint64(i)))
+	for {
+		if word != 0 {
+			// Once complemented, the extra bits of the last word are 1s, so
+			// we must check we didn't find one of those.
+			j := w*64 + bits.TrailingZeros64(word)
+			if j >= bs.length {
+				return 0, false
+			}
+			return j, true
+		}
+		w++
+		if w == len(bs.data) {
+			return 0, false
+		}
+		word = ^bs.data[w]
+	}
+}
+
+// PrevSet returns the index of the last set bit at or before index i, and
+// true, or 0 and false if there's no such bit. The search starts at the last
+// bit if i is greater than or equal to bs.Len().
+func (bs *Bitstring) PrevSet(i int) (int, bool) {
+	if i >= bs.length {
+		i = bs.length - 1
+	}
+	if i < 0 {
+		return 0, false
+	}
+
+	w := int(wordoffset(uint64(i)))
+	word := bs.data[w] & lomask(bitoffset(uint64(i))+1)
+	for {
+		if word != 0 {
+			return w*64 + 63 - bits.LeadingZeros64(word), true
+		}
+		w--
+		if w < 0 {
+			return 0, false
+		}
+		word = bs.data[w]
+	}
+}
+
+// PrevClear returns the index of the last cleared bit at or before index i,
+// and true, or 0 and false if there's no such bit. The search starts at the
+// last bit if i is greater than or equal to bs.Len().
+func (bs *Bitstring) PrevClear(i int) (int, bool) {
+	if i >= bs.length {
+		i = bs.length - 1
+	}
+	if i < 0 {
+		return 0, false
+	}
+
+	// Bits past index i are masked out, so are the extra bits of the last word.
+	w := int(wordoffset(uint64(i)))
+	word := ^bs.data[w] & lomask(bitoffset(uint64(i))+1)
+	for {
+		if word != 0 {
+			return w*64 + 63 - bits.LeadingZeros64(word), true
+		}
+		w--
+		if w < 0 {
+			return 0, false
+		}
+		word = ^bs.data[w]
+	}
+}
+
+// All returns an iterator over the indices and values of all the bits of bs,
+// in increasing index order.
+func (bs *Bitstring) All() iter.Seq2[int, bool] {
+	return func(yield func(int, bool) bool) {
+		for w, word := range bs.data {
+			n := min(64, bs.length-w*64)
+			for i := 0; i < n; i++ {
+				if !yield(w*64+i, word&1 != 0) {
+					return
+				}
+				word >>= 1
+			}
+		}
+	}
+}
+
+// Ones returns an iterator over the indices of the set bits of bs, in
+// increasing order.
+func (bs *Bitstring) Ones() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for w, word := range bs.data {
+			for word != 0 {
+				if !yield(w*64 + bits.TrailingZeros64(word)) {
+					return
+				}
+				word &= word - 1 // clear the lowest set bit
+			}
+		}
+	}
+}
+
+// Zeroes returns an iterator over the indices of the cleared bits of bs, in
+// increasing order.
+func (bs *Bitstring) Zeroes() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		last := len(bs.data) - 1
+		for w, word := range bs.data {
+			word = ^word
+			if w == last {
+				// Do not report the extra bits of the last word.
+				if nused := bitoffset(uint64(bs.length)); nused != 0 {
+					word &= lomask(nused)
+				}
+			}
+			for word != 0 {
+				if !yield(w*64 + bits.TrailingZeros64(word)) {
+					return
+				}
+				word &= word - 1 // clear the lowest set bit
+			}
+		}
+	}
+}
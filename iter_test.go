@@ -0,0 +1,150 @@
+package bitstring
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sparse returns a bitstring of the given length where only the bits at the
+// given indices are set.
+func sparse(length int, ones ...int) *Bitstring {
+	bs := New(length)
+	for _, i := range ones {
+		bs.SetBit(i)
+	}
+	return bs
+}
+
+func TestNextPrev(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	tests := []*Bitstring{
+		New(0),
+		New(1),
+		sparse(1, 0),
+		New(130),
+		sparse(130, 0, 63, 64, 129),
+		sparse(200, 150),
+		Random(64, rng),
+		Random(65, rng),
+		Random(300, rng),
+	}
+	ones := New(131)
+	ones.SetRange(0, 131)
+	tests = append(tests, ones)
+
+	// Naive versions, bit by bit.
+	next := func(bs *Bitstring, i int, val bool) (int, bool) {
+		for j := max(i, 0); j < bs.Len(); j++ {
+			if bs.Bit(j) == val {
+				return j, true
+			}
+		}
+		return 0, false
+	}
+	prev := func(bs *Bitstring, i int, val bool) (int, bool) {
+		for j := min(i, bs.Len()-1); j >= 0; j-- {
+			if bs.Bit(j) == val {
+				return j, true
+			}
+		}
+		return 0, false
+	}
+
+	for _, bs := range tests {
+		t.Run(fmt.Sprintf("len=%d", bs.Len()), func(t *testing.T) {
+			for i := -2; i < bs.Len()+2; i++ {
+				j, ok := bs.NextSet(i)
+				wj, wok := next(bs, i, true)
+				assert.Equalf(t, wok, ok, "NextSet(%d)", i)
+				assert.Equalf(t, wj, j, "NextSet(%d)", i)
+
+				j, ok = bs.NextClear(i)
+				wj, wok = next(bs, i, false)
+				assert.Equalf(t, wok, ok, "NextClear(%d)", i)
+				assert.Equalf(t, wj, j, "NextClear(%d)", i)
+
+				j, ok = bs.PrevSet(i)
+				wj, wok = prev(bs, i, true)
+				assert.Equalf(t, wok, ok, "PrevSet(%d)", i)
+				assert.Equalf(t, wj, j, "PrevSet(%d)", i)
+
+				j, ok = bs.PrevClear(i)
+				wj, wok = prev(bs, i, false)
+				assert.Equalf(t, wok, ok, "PrevClear(%d)", i)
+				assert.Equalf(t, wj, j, "PrevClear(%d)", i)
+			}
+		})
+	}
+}
+
+func TestIterators(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	for _, length := range []int{0, 1, 7, 63, 64, 65, 128, 300} {
+		t.Run(fmt.Sprintf("len=%d", length), func(t *testing.T) {
+			bs := Random(length, rng)
+
+			var wantOnes, wantZeroes []int
+			for i := 0; i < bs.Len(); i++ {
+				if bs.Bit(i) {
+					wantOnes = append(wantOnes, i)
+				} else {
+					wantZeroes = append(wantZeroes, i)
+				}
+			}
+
+			var gotOnes, gotZeroes []int
+			n := 0
+			for i, bit := range bs.All() {
+				assert.Equal(t, n, i)
+				assert.Equal(t, bs.Bit(i), bit)
+				n++
+			}
+			assert.Equal(t, bs.Len(), n)
+
+			for i := range bs.Ones() {
+				gotOnes = append(gotOnes, i)
+			}
+			for i := range bs.Zeroes() {
+				gotZeroes = append(gotZeroes, i)
+			}
+			assert.Equal(t, wantOnes, gotOnes)
+			assert.Equal(t, wantZeroes, gotZeroes)
+		})
+	}
+
+	t.Run("break", func(t *testing.T) {
+		bs := sparse(200, 3, 70, 140)
+
+		var got []int
+		for i := range bs.Ones() {
+			if i > 100 {
+				break
+			}
+			got = append(got, i)
+		}
+		assert.Equal(t, []int{3, 70}, got)
+
+		got = nil
+		for i := range bs.Zeroes() {
+			if i == 5 {
+				break
+			}
+			got = append(got, i)
+		}
+		assert.Equal(t, []int{0, 1, 2, 4}, got)
+
+		n := 0
+		for i := range bs.All() {
+			if i == 10 {
+				break
+			}
+			n++
+		}
+		assert.Equal(t, 10, n)
+	})
+}
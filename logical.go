@@ -0,0 +1,114 @@
+package bitstring
+
+import "fmt"
+
+// And sets bs to the bitwise AND of x and y (x & y) and returns bs.
+//
+// x and y must have the same length, And panics otherwise. bs is resized to
+// the length of x if necessary. bs may be x or y, so bs.And(bs, y) performs the
+// operation in-place.
+func (bs *Bitstring) And(x, y *Bitstring) *Bitstring {
+	mustSameLength(x, y)
+	bs.reset(x.length)
+
+	z, x1, y1 := bs.data, x.data[:len(bs.data)], y.data[:len(bs.data)]
+	for i := range z {
+		z[i] = x1[i] & y1[i]
+	}
+	bs.mustHaveClearPadding()
+	return bs
+}
+
+// Or sets bs to the bitwise OR of x and y (x | y) and returns bs.
+//
+// x and y must have the same length, Or panics otherwise. bs is resized to the
+// length of x if necessary. bs may be x or y, so bs.Or(bs, y) performs the
+// operation in-place.
+func (bs *Bitstring) Or(x, y *Bitstring) *Bitstring {
+	mustSameLength(x, y)
+	bs.reset(x.length)
+
+	z, x1, y1 := bs.data, x.data[:len(bs.data)], y.data[:len(bs.data)]
+	for i := range z {
+		z[i] = x1[i] | y1[i]
+	}
+	bs.mustHaveClearPadding()
+	return bs
+}
+
+// Xor sets bs to the bitwise XOR of x and y (x ^ y) and returns bs.
+//
+// x and y must have the same length, Xor panics otherwise. bs is resized to the
+// length of x if necessary. bs may be x or y, so bs.Xor(bs, y) performs the
+// operation in-place.
+func (bs *Bitstring) Xor(x, y *Bitstring) *Bitstring {
+	mustSameLength(x, y)
+	bs.reset(x.length)
+
+	z, x1, y1 := bs.data, x.data[:len(bs.data)], y.data[:len(bs.data)]
+	for i := range z {
+		z[i] = x1[i] ^ y1[i]
+	}
+	bs.mustHaveClearPadding()
+	return bs
+}
+
+// AndNot sets bs to the bitwise AND NOT of x and y (x &^ y) and returns bs.
+// That is, bits set in y are cleared in x.
+//
+// x and y must have the same length, AndNot panics otherwise. bs is resized to
+// the length of x if necessary. bs may be x or y, so bs.AndNot(bs, y) performs
+// the operation in-place.
+func (bs *Bitstring) AndNot(x, y *Bitstring) *Bitstring {
+	mustSameLength(x, y)
+	bs.reset(x.length)
+
+	z, x1, y1 := bs.data, x.data[:len(bs.data)], y.data[:len(bs.data)]
+	for i := range z {
+		z[i] = x1[i] &^ y1[i]
+	}
+	bs.mustHaveClearPadding()
+	return bs
+}
+
+// Not sets bs to the bitwise complement of x (^x) and returns bs.
+//
+// bs is resized to the length of x if necessary. bs may be x, so bs.Not(bs)
+// performs the operation in-place.
+func (bs *Bitstring) Not(x *Bitstring) *Bitstring {
+	bs.reset(x.length)
+
+	z, x1 := bs.data, x.data[:len(bs.data)]
+	for i := range z {
+		z[i] = ^x1[i]
+	}
+
+	// Complementing sets the out-of-bounds bits of the last word, clear them.
+	bs.clearPadding()
+	bs.mustHaveClearPadding()
+	return bs
+}
+
+// reset sets the length of bs to length, reusing the underlying slice if
+// possible. The content of bs is undefined after reset, it's the caller
+// responsibility to overwrite all words.
+func (bs *Bitstring) reset(length int) {
+	if bs.length == length {
+		return
+	}
+
+	nw := nwords(length)
+	if nw > cap(bs.data) {
+		bs.data = make([]uint64, nw)
+	} else {
+		bs.data = bs.data[:nw]
+	}
+	bs.length = length
+}
+
+// mustSameLength panics if x and y do not have the same length.
+func mustSameLength(x, y *Bitstring) {
+	if x.length != y.length {
+		panic(fmt.Sprintf("Bitstring: length mismatch (%d != %d)", x.length, y.length))
+	}
+}
@@ -0,0 +1,104 @@
+package bitstring
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogical(t *testing.T) {
+	tests := []struct {
+		x, y                      string
+		and, or, xor, andnot, not string
+	}{
+		{
+			x:      "1",
+			y:      "0",
+			and:    "0",
+			or:     "1",
+			xor:    "1",
+			andnot: "1",
+			not:    "0",
+		},
+		{
+			x:      "1100",
+			y:      "1010",
+			and:    "1000",
+			or:     "1110",
+			xor:    "0110",
+			andnot: "0100",
+			not:    "0011",
+		},
+		{
+			x:      "1111111111111111111111111111111100000000000000000000000000000000",
+			y:      "1010101010101010101010101010101010101010101010101010101010101010",
+			and:    "1010101010101010101010101010101000000000000000000000000000000000",
+			or:     "1111111111111111111111111111111110101010101010101010101010101010",
+			xor:    "0101010101010101010101010101010110101010101010101010101010101010",
+			andnot: "0101010101010101010101010101010100000000000000000000000000000000",
+			not:    "0000000000000000000000000000000011111111111111111111111111111111",
+		},
+		{
+			x:      "110" + strings.Repeat("0", 64) + "01",
+			y:      "101" + strings.Repeat("1", 64) + "11",
+			and:    "100" + strings.Repeat("0", 64) + "01",
+			or:     "111" + strings.Repeat("1", 64) + "11",
+			xor:    "011" + strings.Repeat("1", 64) + "10",
+			andnot: "010" + strings.Repeat("0", 64) + "00",
+			not:    "001" + strings.Repeat("1", 64) + "10",
+		},
+	}
+
+	for _, tt := range tests {
+		x, _ := NewFromString(tt.x)
+		y, _ := NewFromString(tt.y)
+
+		ops := []struct {
+			name string
+			op   func(z, x, y *Bitstring) *Bitstring
+			want string
+		}{
+			{"and", (*Bitstring).And, tt.and},
+			{"or", (*Bitstring).Or, tt.or},
+			{"xor", (*Bitstring).Xor, tt.xor},
+			{"andnot", (*Bitstring).AndNot, tt.andnot},
+			{"not", func(z, x, _ *Bitstring) *Bitstring { return z.Not(x) }, tt.not},
+		}
+		for _, op := range ops {
+			t.Run(op.name, func(t *testing.T) {
+				want, _ := NewFromString(op.want)
+
+				// zero-value destination
+				var z Bitstring
+				got := op.op(&z, x, y)
+				assert.True(t, got == &z)
+				equalbits(t, &z, want)
+
+				// larger destination, with extra bits set.
+				z2 := Random(len(tt.x)+100, rand.New(rand.NewSource(99)))
+				op.op(z2, x, y)
+				equalbits(t, z2, want)
+
+				// in-place
+				x2 := x.Clone()
+				op.op(x2, x2, y)
+				equalbits(t, x2, want)
+
+				// operands are left untouched.
+				assert.Equal(t, tt.x, x.String())
+				assert.Equal(t, tt.y, y.String())
+			})
+		}
+	}
+}
+
+func TestLogicalLengthMismatch(t *testing.T) {
+	x, y := New(10), New(11)
+
+	assert.Panics(t, func() { New(10).And(x, y) })
+	assert.Panics(t, func() { New(10).Or(x, y) })
+	assert.Panics(t, func() { New(10).Xor(x, y) })
+	assert.Panics(t, func() { New(10).AndNot(x, y) })
+}
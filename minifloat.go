@@ -0,0 +1,175 @@
+package bitstring
+
+import (
+	"fmt"
+	"math"
+)
+
+// FloatFormat describes a binary floating-point format following the IEEE 754
+// encoding rules: from the most significant bit, a sign bit, ExpBits bits of
+// biased exponent and MantBits bits of mantissa (the fraction, without the
+// implicit leading bit).
+//
+// An exponent field of all zeroes encodes zeroes and subnormal numbers, an
+// exponent field of all ones encodes infinities (zero mantissa) and NaNs
+// (non-zero mantissa).
+//
+// If FiniteOnly is set, the format has no infinities and the highest exponent
+// encodes normal numbers, but for the all ones exponent and mantissa that
+// encodes NaN. This is how the OCP E4M3 8-bit float, used in machine learning,
+// extends its range up to 448.
+//
+// ExpBits must be in [1, 11] and MantBits in [1, 52], so that all values can
+// be represented exactly by a float64 (as long as Bias doesn't push them out
+// of the float64 range).
+type FloatFormat struct {
+	ExpBits    int  // number of exponent bits
+	MantBits   int  // number of mantissa bits, excluding the implicit bit
+	Bias       int  // exponent bias
+	FiniteOnly bool // no infinities, a single NaN mantissa
+}
+
+// Predefined floating-point formats.
+var (
+	Binary16 = FloatFormat{ExpBits: 5, MantBits: 10, Bias: 15}    // IEEE 754 half precision
+	BFloat16 = FloatFormat{ExpBits: 8, MantBits: 7, Bias: 127}    // bfloat16, float32 truncated to 16 bits
+	Binary32 = FloatFormat{ExpBits: 8, MantBits: 23, Bias: 127}   // IEEE 754 single precision
+	Binary64 = FloatFormat{ExpBits: 11, MantBits: 52, Bias: 1023} // IEEE 754 double precision
+
+	E4M3 = FloatFormat{ExpBits: 4, MantBits: 3, Bias: 7, FiniteOnly: true} // OCP 8-bit float, no infinities, max 448
+	E5M2 = FloatFormat{ExpBits: 5, MantBits: 2, Bias: 15}                  // OCP 8-bit float, IEEE 754-like
+)
+
+// Width returns the total number of bits of a number in the f format.
+func (f FloatFormat) Width() int { return 1 + f.ExpBits + f.MantBits }
+
+// check returns an error wrapping ErrWidth if f is not a valid FloatFormat.
+func (f FloatFormat) check() error {
+	if f.ExpBits < 1 || f.ExpBits > 11 || f.MantBits < 1 || f.MantBits > 52 {
+		return fmt.Errorf("%w: unsupported FloatFormat exponent/mantissa widths (%d/%d)", ErrWidth, f.ExpBits, f.MantBits)
+	}
+	return nil
+}
+
+func (f FloatFormat) mustValid() {
+	if err := f.check(); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Float interprets the f.Width() bits at offset off as a floating-point number
+// in the f format, in big endian, and returns its value. Behavior is undefined
+// if there aren't enough bits. Panics if f is not a valid FloatFormat.
+func (bs *Bitstring) Float(off int, f FloatFormat) float64 {
+	f.mustValid()
+
+	bits := bs.Uintn(off, f.Width())
+	mbits := uint64(f.MantBits)
+	maxexp := int(lomask(uint64(f.ExpBits)))
+
+	mant := bits & lomask(mbits)
+	exp := int(bits>>mbits) & maxexp
+
+	var v float64
+	switch {
+	case exp == 0:
+		// Zero or subnormal.
+		v = math.Ldexp(float64(mant), 1-f.Bias-f.MantBits)
+	case exp == maxexp && f.FiniteOnly:
+		if mant == lomask(mbits) {
+			v = math.NaN()
+		} else {
+			v = math.Ldexp(float64(mant|1<<mbits), exp-f.Bias-f.MantBits)
+		}
+	case exp == maxexp:
+		if mant == 0 {
+			v = math.Inf(1)
+		} else {
+			// Keep the NaN payload (and the quiet bit) in the top bits of the
+			// float64 mantissa.
+			v = math.Float64frombits(0x7ff<<52 | mant<<(52-mbits))
+		}
+	default:
+		v = math.Ldexp(float64(mant|1<<mbits), exp-f.Bias-f.MantBits)
+	}
+
+	if bits>>(mbits+uint64(f.ExpBits)) != 0 {
+		v = math.Copysign(v, -1)
+	}
+	return v
+}
+
+// SetFloat sets the f.Width() bits at offset off with val converted to the f
+// format, in big endian. val is rounded to the nearest representable value,
+// ties to even. Values too large for the format become infinities, values too
+// small become subnormals or zeroes. NaNs stay NaNs, keeping as much of their
+// payload as possible. In FiniteOnly formats, values too large, infinities
+// included, saturate to the largest finite value and all NaNs become the
+// single NaN encoding. Behavior is undefined if there aren't enough bits.
+// Panics if f is not a valid FloatFormat.
+func (bs *Bitstring) SetFloat(off int, f FloatFormat, val float64) {
+	f.mustValid()
+
+	mbits := uint64(f.MantBits)
+	maxexp := lomask(uint64(f.ExpBits))
+	inf := maxexp << mbits
+
+	// top is the encoding of values too large for the format.
+	top := inf
+	if f.FiniteOnly {
+		top = inf | lomask(mbits) - 1
+	}
+
+	var bits uint64
+	switch {
+	case math.IsNaN(val) && f.FiniteOnly:
+		bits = inf | lomask(mbits)
+	case math.IsNaN(val):
+		mant := math.Float64bits(val) & lomask(52) >> (52 - mbits)
+		if mant == 0 {
+			mant = 1 << (mbits - 1) // quiet NaN
+		}
+		bits = inf | mant
+	case math.IsInf(val, 0):
+		bits = top
+	case val == 0:
+		bits = 0
+	default:
+		// |val| = sig * 2^(exp-53), sig has 53 significant bits.
+		frac, exp := math.Frexp(math.Abs(val))
+		sig := uint64(math.Ldexp(frac, 53))
+
+		be := exp - 1 + f.Bias // biased exponent
+		if be >= 1 {
+			// Normal. If rounding carries over the implicit bit, it
+			// increments the exponent field, as it should.
+			q := roundShift(sig, 52-mbits)
+			bits = min(uint64(be-1)<<mbits+q, top)
+		} else {
+			// Subnormal. If rounding reaches 1<<mbits, this is the smallest
+			// normal number.
+			if s := 52 - mbits + uint64(1-be); s < 64 {
+				bits = roundShift(sig, s)
+			}
+		}
+	}
+
+	if math.Signbit(val) {
+		bits |= 1 << (mbits + uint64(f.ExpBits))
+	}
+	bs.SetUintn(off, f.Width(), bits)
+}
+
+// roundShift returns x>>s rounded to the nearest integer, ties to even.
+// s must be less than 64.
+func roundShift(x, s uint64) uint64 {
+	if s == 0 {
+		return x
+	}
+	q := x >> s
+	rem, half := x&lomask(s), uint64(1)<<(s-1)
+	if rem > half || (rem == half && q&1 == 1) {
+		q++
+	}
+	return q
+}
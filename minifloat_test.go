@@ -0,0 +1,228 @@
+package bitstring
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloatFormatBinary16(t *testing.T) {
+	tests := []struct {
+		val  float64
+		bits uint64
+		back float64 // value read back
+	}{
+		{val: 0, bits: 0x0000, back: 0},
+		{val: 1, bits: 0x3c00, back: 1},
+		{val: -2, bits: 0xc000, back: -2},
+		{val: 0.1, bits: 0x2e66, back: 0.0999755859375},
+		{val: 65504, bits: 0x7bff, back: 65504},
+		{val: 65519, bits: 0x7bff, back: 65504},
+		{val: 65520, bits: 0x7c00, back: math.Inf(1)}, // rounds up to infinity
+		{val: 1e10, bits: 0x7c00, back: math.Inf(1)},
+		{val: math.Inf(-1), bits: 0xfc00, back: math.Inf(-1)},
+		{val: 0x1p-14, bits: 0x0400, back: 0x1p-14},         // smallest normal
+		{val: 0x1p-24, bits: 0x0001, back: 0x1p-24},         // smallest subnormal
+		{val: 0x1p-25, bits: 0x0000, back: 0},               // tie, rounds to even
+		{val: 0x1.8p-25, bits: 0x0001, back: 0x1p-24},       // above the tie
+		{val: 0x3p-25, bits: 0x0002, back: 0x1p-23},         // tie, rounds to even
+		{val: 0x1.ff8p-15, bits: 0x03ff, back: 0x1.ff8p-15}, // largest subnormal
+		{val: 0x1.ffcp-15, bits: 0x0400, back: 0x1p-14},     // tie, rounds to even
+		{val: 0x1.ffep-15, bits: 0x0400, back: 0x1p-14},     // rounds up to smallest normal
+		{val: 1 + 0x1p-11, bits: 0x3c00, back: 1},           // tie, rounds to even
+		{val: 1 + 0x3p-11, bits: 0x3c02, back: 1 + 0x1p-9},
+		{val: math.SmallestNonzeroFloat64, bits: 0x0000, back: 0},
+		{val: -math.SmallestNonzeroFloat64, bits: 0x8000, back: math.Copysign(0, -1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.val), func(t *testing.T) {
+			bs := New(80)
+			bs.SetRange(0, 80)
+			bs.SetFloat(61, Binary16, tt.val)
+			assert.Equalf(t, tt.bits, bs.Uintn(61, 16), "got %#04x want %#04x", bs.Uintn(61, 16), tt.bits)
+			got := bs.Float(61, Binary16)
+			assert.Equal(t, tt.back, got)
+			assert.Equal(t, math.Signbit(tt.back), math.Signbit(got))
+			// Other bits are left untouched.
+			assert.Equal(t, 80-16, bs.OnesCount()-bits.OnesCount64(tt.bits))
+		})
+	}
+
+	// NaN
+	bs := New(16)
+	bs.SetFloat(0, Binary16, math.NaN())
+	assert.Equal(t, uint16(0x7e00), bs.Uint16(0)&0x7e00)
+	assert.True(t, math.IsNaN(bs.Float(0, Binary16)))
+}
+
+// Reference encodings from the OCP 8-bit floating point specification.
+func TestFloatFormatE4M3(t *testing.T) {
+	tests := []struct {
+		val  float64
+		bits uint64
+		back float64 // value read back
+	}{
+		{val: 0, bits: 0x00, back: 0},
+		{val: 1, bits: 0x38, back: 1},
+		{val: -0.5, bits: 0xb0, back: -0.5},
+		{val: 240, bits: 0x77, back: 240},
+		{val: 256, bits: 0x78, back: 256}, // top exponent encodes normal numbers
+		{val: 416, bits: 0x7d, back: 416},
+		{val: 448, bits: 0x7e, back: 448}, // largest finite value
+		{val: -448, bits: 0xfe, back: -448},
+		{val: 464, bits: 0x7e, back: 448}, // saturates instead of rounding up
+		{val: 1e6, bits: 0x7e, back: 448},
+		{val: math.Inf(1), bits: 0x7e, back: 448},
+		{val: math.Inf(-1), bits: 0xfe, back: -448},
+		{val: 0x1p-6, bits: 0x08, back: 0x1p-6},  // smallest normal
+		{val: 0x7p-9, bits: 0x07, back: 0x7p-9},  // largest subnormal
+		{val: 0x1p-9, bits: 0x01, back: 0x1p-9},  // smallest subnormal
+		{val: 0x1p-10, bits: 0x00, back: 0},      // tie, rounds to even
+		{val: 0x3p-11, bits: 0x01, back: 0x1p-9}, // above the tie
+		{val: 1.0625, bits: 0x38, back: 1},       // tie, rounds to even
+		{val: 1.1875, bits: 0x3a, back: 1.25},    // tie, rounds to even
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.val), func(t *testing.T) {
+			bs := New(20)
+			bs.SetFloat(5, E4M3, tt.val)
+			assert.Equalf(t, tt.bits, bs.Uintn(5, 8), "got %#02x want %#02x", bs.Uintn(5, 8), tt.bits)
+			got := bs.Float(5, E4M3)
+			assert.Equal(t, tt.back, got)
+			assert.Equal(t, math.Signbit(tt.back), math.Signbit(got))
+		})
+	}
+
+	// A single NaN encoding, with either sign.
+	bs := New(8)
+	bs.SetFloat(0, E4M3, math.NaN())
+	assert.Equal(t, uint8(0x7f), bs.Uint8(0))
+	bs.SetFloat(0, E4M3, math.Copysign(math.NaN(), -1))
+	assert.Equal(t, uint8(0xff), bs.Uint8(0))
+	for _, b := range []uint8{0x7f, 0xff} {
+		bs.SetUint8(0, b)
+		assert.True(t, math.IsNaN(bs.Float(0, E4M3)))
+	}
+
+	// All other encodings are finite.
+	for b := 0; b < 256; b++ {
+		bs.SetUint8(0, uint8(b))
+		if b&0x7f != 0x7f {
+			v := bs.Float(0, E4M3)
+			assert.False(t, math.IsNaN(v) || math.IsInf(v, 0), "%#02x", b)
+			assert.LessOrEqual(t, math.Abs(v), 448.0)
+		}
+	}
+}
+
+func TestFloatFormatE5M2(t *testing.T) {
+	bs := New(8)
+	for _, tt := range []struct {
+		val  float64
+		bits uint8
+	}{
+		{1, 0x3c}, {57344, 0x7b}, {-57344, 0xfb}, {0x1p-16, 0x01}, {math.Inf(1), 0x7c}, {1e6, 0x7c},
+	} {
+		bs.SetFloat(0, E5M2, tt.val)
+		assert.Equalf(t, tt.bits, bs.Uint8(0), "SetFloat(%v)", tt.val)
+	}
+}
+
+// Binary32 conversions must agree with Go float32 conversions, that round to
+// nearest even.
+func TestFloatFormatBinary32(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	vals := []float64{
+		0, math.Copysign(0, -1), 1, -1, math.MaxFloat32, -math.MaxFloat32,
+		math.SmallestNonzeroFloat32, math.SmallestNonzeroFloat32 / 2,
+		math.SmallestNonzeroFloat32 * 0.75, 0x1p-126, 0x1.fffffep-127,
+		math.MaxFloat32 * (1 + 0x1p-25), math.MaxFloat32 * (1 + 0x1p-24),
+		math.Inf(1), math.Inf(-1), 1e300, 1e-300,
+	}
+	for i := 0; i < 1000; i++ {
+		// Random float64 bits, around the float32 range.
+		v := math.Float64frombits(rng.Uint64())
+		if math.Abs(v) > 1e40 || math.Abs(v) < 1e-50 {
+			v = math.Ldexp(rng.Float64(), rng.Intn(300)-160)
+		}
+		vals = append(vals, v)
+	}
+
+	bs := New(100)
+	for _, v := range vals {
+		bs.SetFloat(37, Binary32, v)
+		want := math.Float32bits(float32(v))
+		assert.Equalf(t, want, bs.Uint32(37), "SetFloat(%v)", v)
+
+		got := bs.Float(37, Binary32)
+		assert.Equal(t, math.Float64bits(float64(float32(v))), math.Float64bits(got))
+	}
+
+	// All exponents, random mantissas, decoding.
+	for i := 0; i < 1000; i++ {
+		bits := rng.Uint32()
+		bs.SetUint32(5, bits)
+		want := float64(math.Float32frombits(bits))
+		got := bs.Float(5, Binary32)
+		if math.IsNaN(want) {
+			assert.True(t, math.IsNaN(got))
+			continue
+		}
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestFloatFormatRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	formats := []FloatFormat{
+		Binary16, BFloat16, Binary32, Binary64,
+		E4M3, E5M2,
+		{ExpBits: 4, MantBits: 3, Bias: 7},     // 8-bit IEEE-like E4M3
+		{ExpBits: 1, MantBits: 1, Bias: 0},     // 3 bits
+		{ExpBits: 11, MantBits: 1, Bias: 1050}, // odd widths and bias
+	}
+
+	for _, f := range formats {
+		t.Run(fmt.Sprintf("%+v", f), func(t *testing.T) {
+			n := f.Width()
+			bs := New(n + 70)
+			for i := 0; i < 500; i++ {
+				// Every encoding decodes and re-encodes to itself, NaNs
+				// included.
+				off := rng.Intn(70)
+				bits := rng.Uint64() & lomask(uint64(n))
+				bs.SetUintn(off, n, bits)
+				v := bs.Float(off, f)
+				bs.SetFloat(off, f, v)
+				assert.Equalf(t, bits, bs.Uintn(off, n), "%v", v)
+			}
+		})
+	}
+
+	// bfloat16 is the top half of float32, when no rounding is needed.
+	bs := New(16)
+	for i := 0; i < 100; i++ {
+		v := float64(math.Float32frombits(rng.Uint32() &^ 0xffff))
+		if math.IsNaN(v) {
+			continue
+		}
+		bs.SetFloat(0, BFloat16, v)
+		assert.Equal(t, math.Float32bits(float32(v))>>16, uint32(bs.Uint16(0)))
+	}
+}
+
+func TestFloatFormatInvalid(t *testing.T) {
+	bs := New(64)
+	assert.Panics(t, func() { bs.Float(0, FloatFormat{ExpBits: 0, MantBits: 10}) })
+	assert.Panics(t, func() { bs.Float(0, FloatFormat{ExpBits: 12, MantBits: 10}) })
+	assert.Panics(t, func() { bs.SetFloat(0, FloatFormat{ExpBits: 5, MantBits: 0}, 1) })
+	assert.Panics(t, func() { bs.SetFloat(0, FloatFormat{ExpBits: 5, MantBits: 53}, 1) })
+}
@@ -1,5 +1,18 @@
+//go:build !bitstring_debug
 // +build !bitstring_debug
 
 package bitstring
 
 func (bs *Bitstring) mustExist(i int) {}
+
+func (bs *Bitstring) mustExistRange(off, n int) {}
+
+func (bs *Bitstring) mustHaveClearPadding() {}
+
+func mustNotNegative(off, n int) {}
+
+func mustHaveSameLength(x, y *Bitstring) {}
+
+func (v View) mustExist(i int) {}
+
+func (v View) mustExistRange(off, n int) {}
@@ -0,0 +1,133 @@
+package bitstring
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// FieldOrder specifies how the bits of a multi-bit field are numbered and
+// assembled into a value, see UintnOrder and SetUintnOrder. A FieldOrder is
+// made of a bit numbering (LSB0 or MSB0) and a byte order (BigEndian or
+// LittleEndian), combined with |.
+type FieldOrder uint
+
+const (
+	// LSB0 numbers bits from the least significant bit of the Bitstring, the
+	// rightmost in its string representation: bit 0 is the least significant
+	// bit. A field at offset off spans bits [off, off+n). This is the
+	// numbering used by all other accessors.
+	LSB0 FieldOrder = 0
+
+	// BigEndian assembles the field value with the most significant bit at
+	// the highest LSB0 offset, that is on the left of the string
+	// representation. This is the byte order used by all other accessors.
+	BigEndian FieldOrder = 0
+
+	// MSB0 numbers bits from the most significant bit of the Bitstring, the
+	// leftmost in its string representation, as in most network protocol
+	// specifications: bit i in MSB0 is bit Len()-1-i in LSB0. A field at
+	// offset off spans MSB0 bits [off, off+n), its first bit being the most
+	// significant (in BigEndian).
+	MSB0 FieldOrder = 1 << 0
+
+	// LittleEndian reverses the order of the bytes of the field: its least
+	// significant byte is the leftmost in the string representation. Fields
+	// must be made of whole bytes.
+	LittleEndian FieldOrder = 1 << 1
+)
+
+// String returns the name of the field order.
+func (o FieldOrder) String() string {
+	if o&^(MSB0|LittleEndian) != 0 {
+		return fmt.Sprintf("FieldOrder(%d)", uint(o))
+	}
+
+	var sb strings.Builder
+	if o&MSB0 != 0 {
+		sb.WriteString("MSB0|")
+	} else {
+		sb.WriteString("LSB0|")
+	}
+	if o&LittleEndian != 0 {
+		sb.WriteString("LittleEndian")
+	} else {
+		sb.WriteString("BigEndian")
+	}
+	return sb.String()
+}
+
+// check returns an error if o is not a valid FieldOrder for an n-bit field.
+func (o FieldOrder) check(n int) error {
+	if o&^(MSB0|LittleEndian) != 0 {
+		return fmt.Errorf("invalid field order: %v", o)
+	}
+	if o&LittleEndian != 0 && n%8 != 0 {
+		return fmt.Errorf("%w: LittleEndian fields must be made of whole bytes", ErrWidth)
+	}
+	return nil
+}
+
+func (o FieldOrder) mustValid(n int) {
+	if err := o.check(n); err != nil {
+		panic(err.Error())
+	}
+}
+
+// fieldOffset returns the LSB0 offset of the n-bit field at offset off in the
+// given order.
+func (bs *Bitstring) fieldOffset(off, n int, order FieldOrder) int {
+	if order&MSB0 != 0 {
+		return bs.length - off - n
+	}
+	return off
+}
+
+// swapBytes reverses the order of the n/8 bytes of the n-bit value v.
+func swapBytes(v uint64, n int) uint64 {
+	return bits.ReverseBytes64(v) >> (64 - n)
+}
+
+// UintnOrder interprets the n bits at offset off, in the given order, as an
+// n-bit unsigned integer and returns its value. Behavior is undefined if there
+// aren't enough bits. Panics if nbits is greater than 64, if order is not a
+// valid FieldOrder or if order is LittleEndian and n is not a multiple of 8.
+func (bs *Bitstring) UintnOrder(off, n int, order FieldOrder) uint64 {
+	order.mustValid(n)
+
+	v := bs.Uintn(bs.fieldOffset(off, n, order), n)
+	if order&LittleEndian != 0 {
+		v = swapBytes(v, n)
+	}
+	return v
+}
+
+// IntnOrder interprets the n bits at offset off, in the given order, as an
+// n-bit signed integer and returns its value. Behavior is undefined if there
+// aren't enough bits. Panics if nbits is greater than 64, if order is not a
+// valid FieldOrder or if order is LittleEndian and n is not a multiple of 8.
+func (bs *Bitstring) IntnOrder(off, n int, order FieldOrder) int64 {
+	s := uint(64 - n)
+	return int64(bs.UintnOrder(off, n, order)<<s) >> s
+}
+
+// SetUintnOrder sets the n bits at offset off, in the given order, with the
+// given n-bit unsigned integer. Behavior is undefined if there aren't enough
+// bits. Panics if nbits is greater than 64, if order is not a valid
+// FieldOrder or if order is LittleEndian and n is not a multiple of 8.
+func (bs *Bitstring) SetUintnOrder(off, n int, val uint64, order FieldOrder) {
+	order.mustValid(n)
+
+	if order&LittleEndian != 0 {
+		val = swapBytes(val&lomask(uint64(n)), n)
+	}
+	bs.SetUintn(bs.fieldOffset(off, n, order), n, val)
+}
+
+// SetIntnOrder sets the n bits at offset off, in the given order, with the
+// given n-bit signed integer. Behavior is undefined if there aren't enough
+// bits. Panics if nbits is greater than 64, if order is not a valid
+// FieldOrder or if order is LittleEndian and n is not a multiple of 8.
+func (bs *Bitstring) SetIntnOrder(off, n int, val int64, order FieldOrder) {
+	bs.SetUintnOrder(off, n, uint64(val), order)
+}
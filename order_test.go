@@ -0,0 +1,104 @@
+package bitstring
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUintnOrder(t *testing.T) {
+	//                     MSB0 offset 0 ↓            ↓ LSB0 offset 0
+	bs, _ := NewFromString("1011000000010010001101001100")
+
+	tests := []struct {
+		off, n int
+		order  FieldOrder
+		want   uint64
+	}{
+		{off: 0, n: 4, order: LSB0, want: 0b1100},
+		{off: 0, n: 4, order: MSB0, want: 0b1011},
+		{off: 1, n: 3, order: MSB0, want: 0b011},
+		{off: 4, n: 16, order: MSB0, want: 0x0123},
+		{off: 4, n: 16, order: MSB0 | BigEndian, want: 0x0123},
+		{off: 4, n: 16, order: MSB0 | LittleEndian, want: 0x2301},
+		{off: 8, n: 16, order: LSB0, want: 0x0123},
+		{off: 8, n: 16, order: LSB0 | LittleEndian, want: 0x2301},
+		{off: 4, n: 24, order: LSB0, want: 0xb01234},
+		{off: 4, n: 24, order: LSB0 | LittleEndian, want: 0x3412b0},
+		{off: 0, n: 8, order: LittleEndian, want: 0x4c},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d,%d,%v", tt.off, tt.n, tt.order), func(t *testing.T) {
+			assert.Equal(t, tt.want, bs.UintnOrder(tt.off, tt.n, tt.order))
+
+			// Set the value back in a zeroed copy.
+			cpy := New(bs.Len())
+			cpy.SetUintnOrder(tt.off, tt.n, tt.want, tt.order)
+			assert.Equal(t, tt.want, cpy.UintnOrder(tt.off, tt.n, tt.order))
+			assert.Equal(t, bits.OnesCount64(tt.want), cpy.OnesCount())
+		})
+	}
+}
+
+// Fields read in the various orders match encoding/binary reads of the
+// underlying bytes.
+func TestUintnOrderBytes(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	buf := make([]byte, 32)
+	rng.Read(buf)
+
+	// With MSBFirst bytes, the MSB0 offset of byte i is 8*i.
+	msb, _ := NewFromBytes(buf, 8*len(buf), MSBFirst)
+	// With LSBFirst bytes, the LSB0 offset of byte i is 8*i.
+	lsb, _ := NewFromBytes(buf, 8*len(buf), LSBFirst)
+
+	for i := 0; i+8 <= len(buf); i++ {
+		assert.Equal(t, uint64(binary.BigEndian.Uint16(buf[i:])), msb.UintnOrder(8*i, 16, MSB0))
+		assert.Equal(t, uint64(binary.LittleEndian.Uint16(buf[i:])), msb.UintnOrder(8*i, 16, MSB0|LittleEndian))
+		assert.Equal(t, binary.BigEndian.Uint64(buf[i:]), msb.UintnOrder(8*i, 64, MSB0))
+		assert.Equal(t, binary.LittleEndian.Uint64(buf[i:]), msb.UintnOrder(8*i, 64, MSB0|LittleEndian))
+
+		assert.Equal(t, uint64(binary.LittleEndian.Uint32(buf[i:])), lsb.UintnOrder(8*i, 32, LSB0))
+		assert.Equal(t, uint64(binary.BigEndian.Uint32(buf[i:])), lsb.UintnOrder(8*i, 32, LSB0|LittleEndian))
+	}
+}
+
+func TestIntnOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bs := New(150)
+
+	for _, order := range []FieldOrder{LSB0, MSB0, LittleEndian, MSB0 | LittleEndian} {
+		for _, n := range []int{8, 16, 24, 56, 64} {
+			for i := 0; i < 20; i++ {
+				off := rng.Intn(150 - n)
+				val := int64(rng.Uint64()) >> (64 - n)
+				bs.SetIntnOrder(off, n, val, order)
+				assert.Equalf(t, val, bs.IntnOrder(off, n, order), "%v n=%d off=%d", order, n, off)
+			}
+		}
+	}
+
+	bs.SetIntnOrder(3, 12, -2, MSB0)
+	assert.Equal(t, int64(-2), bs.IntnOrder(3, 12, MSB0))
+	assert.Equal(t, uint64(0xffe), bs.UintnOrder(3, 12, MSB0))
+}
+
+func TestFieldOrderPanics(t *testing.T) {
+	bs := New(64)
+	assert.Panics(t, func() { bs.UintnOrder(0, 12, LittleEndian) })
+	assert.Panics(t, func() { bs.SetUintnOrder(0, 12, 0, MSB0|LittleEndian) })
+	assert.Panics(t, func() { bs.UintnOrder(0, 8, FieldOrder(4)) })
+}
+
+func TestFieldOrderString(t *testing.T) {
+	assert.Equal(t, "LSB0|BigEndian", LSB0.String())
+	assert.Equal(t, "MSB0|BigEndian", MSB0.String())
+	assert.Equal(t, "LSB0|LittleEndian", LittleEndian.String())
+	assert.Equal(t, "MSB0|LittleEndian", (MSB0 | LittleEndian).String())
+	assert.Equal(t, "FieldOrder(4)", FieldOrder(4).String())
+}
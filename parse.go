@@ -0,0 +1,182 @@
+package bitstring
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Parse parses s and returns the corresponding Bitstring. Parse accepts the
+// following forms:
+//
+//	"1010"        binary digits, as NewFromString
+//	"0b1010"      binary digits, with a 0b or 0B prefix
+//	"0o17"        octal digits, with a 0o or 0O prefix, 3 bits per digit
+//	"0xabc"       hexadecimal digits, with a 0x or 0X prefix, 4 bits per digit
+//	"12'habc"     Verilog-style sized literal
+//
+// Without an explicit size, the Bitstring length is the number of digits
+// multiplied by the number of bits per digit, so leading zeroes are kept.
+//
+// Sized literals are made of the Bitstring length in decimal, a quote, a base
+// letter (b, o, h or d, case-insensitive) and the digits. The value is
+// zero-extended to the given length, Parse fails if it doesn't fit. The size
+// can't exceed MaxParseLen.
+//
+// In all forms, digits can be separated with '_' or ' ' characters, but at
+// least one digit is required: only the empty string parses to an empty
+// Bitstring. On error, the position of the offending character in s is
+// reported.
+func Parse(s string) (*Bitstring, error) {
+	if i := strings.IndexByte(s, '\''); i >= 0 {
+		return parseSized(s, i)
+	}
+
+	if len(s) >= 2 && s[0] == '0' {
+		switch s[1] {
+		case 'b', 'B':
+			return parseDigits(s, 2, 1)
+		case 'o', 'O':
+			return parseDigits(s, 2, 3)
+		case 'x', 'X':
+			return parseDigits(s, 2, 4)
+		}
+	}
+	return parseDigits(s, 0, 1)
+}
+
+// MaxParseLen is the maximum size of a Verilog-style sized literal accepted by
+// Parse and Scan. Since the size is only bounded by MaxParseLen, a short
+// literal can describe a huge Bitstring: it defaults to 1<<24 bits (2MiB).
+// Larger values are clamped to math.MaxInt-63.
+var MaxParseLen = 1 << 24
+
+// parseSized parses the Verilog-style sized literal s, where quote is the
+// index of the quote character.
+func parseSized(s string, quote int) (*Bitstring, error) {
+	if quote == 0 {
+		return nil, fmt.Errorf("missing size at position 0")
+	}
+
+	size, max := 0, min(MaxParseLen, maxLength)
+	for i, c := range s[:quote] {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("illegal character at position %v: %#U", i, c)
+		}
+		d := int(c - '0')
+		if size > (max-d)/10 {
+			return nil, fmt.Errorf("size exceeds MaxParseLen (%d)", max)
+		}
+		size = size*10 + d
+	}
+
+	if quote+1 == len(s) {
+		return nil, fmt.Errorf("missing base at position %v", quote+1)
+	}
+
+	var (
+		bs  *Bitstring
+		err error
+	)
+	switch base := s[quote+1]; base {
+	case 'b', 'B':
+		bs, err = parseDigits(s, quote+2, 1)
+	case 'o', 'O':
+		bs, err = parseDigits(s, quote+2, 3)
+	case 'h', 'H':
+		bs, err = parseDigits(s, quote+2, 4)
+	case 'd', 'D':
+		bs, err = parseDecimal(s, quote+2)
+	default:
+		c := []rune(s[quote+1:])[0]
+		return nil, fmt.Errorf("illegal base at position %v: %#U", quote+1, c)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if extra := bs.length - size; extra > 0 {
+		if bs.LeadingZeroes() < extra {
+			return nil, fmt.Errorf("value overflows %d bits", size)
+		}
+		bs.Truncate(size)
+	} else {
+		bs.Resize(size)
+	}
+	return bs, nil
+}
+
+// isSep reports whether c is a digit separator.
+func isSep(c rune) bool {
+	return c == '_' || c == ' '
+}
+
+// parseDigits parses s[start:] as digits in base 1<<shift, separated or not
+// with separators. Errors report positions in s.
+func parseDigits(s string, start, shift int) (*Bitstring, error) {
+	// Validate and count digits first, to allocate the Bitstring once.
+	ndigits := 0
+	for i, c := range s[start:] {
+		if isSep(c) {
+			continue
+		}
+		if digitValue(c) >= 1<<shift {
+			return nil, fmt.Errorf("illegal character at position %v: %#U", start+i, c)
+		}
+		ndigits++
+	}
+	if ndigits == 0 && len(s) != 0 {
+		return nil, fmt.Errorf("missing digits at position %v", len(s))
+	}
+
+	// All characters are ASCII now, fill the bitstring from its least
+	// significant digit.
+	bs := New(ndigits * shift)
+	off := 0
+	for i := len(s) - 1; i >= start; i-- {
+		if isSep(rune(s[i])) {
+			continue
+		}
+		if d := digitValue(rune(s[i])); d != 0 {
+			bs.SetUintn(off, shift, uint64(d))
+		}
+		off += shift
+	}
+	return bs, nil
+}
+
+// parseDecimal parses s[start:] as decimal digits, separated or not with
+// separators. The returned Bitstring is as long as the value bit length.
+// Errors report positions in s.
+func parseDecimal(s string, start int) (*Bitstring, error) {
+	var sb strings.Builder
+	for i, c := range s[start:] {
+		if isSep(c) {
+			continue
+		}
+		if digitValue(c) >= 10 {
+			return nil, fmt.Errorf("illegal character at position %v: %#U", start+i, c)
+		}
+		sb.WriteRune(c)
+	}
+	if sb.Len() == 0 {
+		return nil, fmt.Errorf("missing digits at position %v", len(s))
+	}
+
+	bi, _ := new(big.Int).SetString(sb.String(), 10)
+	return NewFromBig(bi), nil
+}
+
+// digitValue returns the value of the hexadecimal digit c, or 16 if c is not
+// an hexadecimal digit.
+func digitValue(c rune) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'f':
+		return int(c - 'a' + 10)
+	case 'A' <= c && c <= 'F':
+		return int(c - 'A' + 10)
+	}
+	return 16
+}
@@ -0,0 +1,169 @@
+package bitstring
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", ""},
+		{"0", "0"},
+		{"1010", "1010"},
+		{"0b1010", "1010"},
+		{"0B0010", "0010"},
+		{"0o17", "001111"},
+		{"0O7", "111"},
+		{"0xabc", "101010111100"},
+		{"0XaBc", "101010111100"},
+		{"0x0f", "00001111"},
+
+		// separators
+		{"1010_0101", "10100101"},
+		{"1010 0101", "10100101"},
+		{"0x_dead_beef", "11011110101011011011111011101111"},
+		{"0b 1 0", "10"},
+
+		// sized literals
+		{"12'habc", "101010111100"},
+		{"7'b1010101", "1010101"},
+		{"8'b101", "00000101"},
+		{"7'h7f", "1111111"},
+		{"7'h07f", "1111111"},
+		{"6'o77", "111111"},
+		{"4'd9", "1001"},
+		{"8'D255", "11111111"},
+		{"10'd0", "0000000000"},
+		{"16'hAB_CD", "1010101111001101"},
+		{"3'b000_000", "000"},
+		{"0'b0", ""},
+		{"70'd590295810358705651712", "1" + strings.Repeat("0", 69)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := Parse(tt.s)
+			require.NoError(t, err)
+			want, _ := NewFromString(tt.want)
+			equalbits(t, got, want)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		s       string
+		wantErr string
+	}{
+		{"1020", "illegal character at position 2: U+0032 '2'"},
+		{"0b12", "illegal character at position 3: U+0032 '2'"},
+		{"0o18", "illegal character at position 3: U+0038 '8'"},
+		{"0xfg", "illegal character at position 3: U+0067 'g'"},
+		{"0x", "missing digits at position 2"},
+		{"0b__", "missing digits at position 4"},
+		{"_", "missing digits at position 1"},
+		{" ", "missing digits at position 1"},
+		{"__", "missing digits at position 2"},
+		{"0x€", "illegal character at position 2: U+20AC '€'"},
+		{"'hff", "missing size at position 0"},
+		{"1x'hff", "illegal character at position 1: U+0078 'x'"},
+		{"8'", "missing base at position 2"},
+		{"8'zff", "illegal base at position 2: U+007A 'z'"},
+		{"8'h", "missing digits at position 3"},
+		{"8'd", "missing digits at position 3"},
+		{"8'd25a", "illegal character at position 5: U+0061 'a'"},
+		{"12'hxyz", "illegal character at position 4: U+0078 'x'"},
+		{"7'hff", "value overflows 7 bits"},
+		{"8'd256", "value overflows 8 bits"},
+		{"99999999999999999999'b1", fmt.Sprintf("size exceeds MaxParseLen (%d)", MaxParseLen)},
+		{"2147483647'b1", fmt.Sprintf("size exceeds MaxParseLen (%d)", MaxParseLen)},
+		{"4294967296'b1", fmt.Sprintf("size exceeds MaxParseLen (%d)", MaxParseLen)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			_, err := Parse(tt.s)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMaxParseLen(t *testing.T) {
+	defer func(max int) { MaxParseLen = max }(MaxParseLen)
+
+	MaxParseLen = 100
+	bs, err := Parse("100'h1")
+	require.NoError(t, err)
+	assert.Equal(t, 100, bs.Len())
+	_, err = Parse("101'h1")
+	assert.EqualError(t, err, "size exceeds MaxParseLen (100)")
+
+	// Sizes can't overflow, whatever MaxParseLen.
+	MaxParseLen = math.MaxInt
+	_, err = Parse(fmt.Sprintf("%d'b1", math.MaxInt))
+	assert.EqualError(t, err, fmt.Sprintf("size exceeds MaxParseLen (%d)", math.MaxInt-63))
+	_, err = Parse(fmt.Sprintf("%d'b1", uint64(math.MaxInt)+1))
+	assert.Error(t, err)
+}
+
+func TestParseNewFromString(t *testing.T) {
+	// Parse and NewFromString agree on binary strings, errors included.
+	for _, s := range []string{"", "0", "1", "10x1", "1 0"} {
+		want, wantErr := NewFromString(s)
+		got, err := Parse(s)
+		if wantErr != nil {
+			if s == "1 0" {
+				// Separators are only accepted by Parse.
+				assert.NoError(t, err)
+				continue
+			}
+			assert.EqualError(t, err, wantErr.Error())
+			continue
+		}
+		equalbits(t, got, want)
+	}
+
+	// Parse round-trips Format output.
+	rng := rand.New(rand.NewSource(99))
+	for _, length := range []int{1, 4, 63, 64, 65, 200} {
+		bs := Random(length, rng)
+
+		got, err := Parse(fmt.Sprintf("%#+b", bs))
+		assert.NoError(t, err)
+		equalbits(t, got, bs)
+
+		got, err = Parse(fmt.Sprintf("%d'h%x", length, bs))
+		assert.NoError(t, err)
+		equalbits(t, got, bs)
+
+		got, err = Parse(fmt.Sprintf("%d'o% o", length, bs))
+		assert.NoError(t, err)
+		equalbits(t, got, bs)
+
+		got, err = Parse(fmt.Sprintf("%d'd%v", length, bs.BigInt()))
+		assert.NoError(t, err)
+		equalbits(t, got, bs)
+	}
+}
+
+func ExampleParse() {
+	for _, s := range []string{"0b1010_0101", "0x1f", "12'habc", "7'b1010101", "8'd200"} {
+		bs, _ := Parse(s)
+		fmt.Println(bs)
+	}
+	// Output:
+	// 10100101
+	// 00011111
+	// 101010111100
+	// 1010101
+	// 11001000
+}
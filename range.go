@@ -1,6 +1,9 @@
 package bitstring
 
-import "math"
+import (
+	"math"
+	"math/bits"
+)
 
 func minuint(x, y uint64) uint64 {
 	if x < y {
@@ -11,11 +14,16 @@ func minuint(x, y uint64) uint64 {
 
 // SwapRange swaps a range of bits between 2 bitstrings.
 //
-// The range [off, off+len) must exist on both bitstrings or SwapRange has
-// undefined behavior.
+// bs1 and bs2 must have the same length and the range [off, off+len) must
+// exist or SwapRange has undefined behavior.
 func SwapRange(bs1, bs2 *Bitstring, off, len int) {
-	bs1.mustExist(off + len - 1)
-	bs2.mustExist(off + len - 1)
+	bs1.mustExistRange(off, len)
+	bs2.mustExistRange(off, len)
+	mustHaveSameLength(bs1, bs2)
+
+	if len == 0 {
+		return
+	}
 
 	// Swap bits in the first word.
 	start, l := uint64(off), uint64(len)
@@ -37,6 +45,8 @@ func SwapRange(bs1, bs2 *Bitstring, off, len int) {
 	if remain != 0 {
 		swapBits(bs1, bs2, i, lomask(remain))
 	}
+	bs1.mustHaveClearPadding()
+	bs2.mustHaveClearPadding()
 }
 
 // swapBits swaps range of bits from one word to another. w is the index of the
@@ -55,9 +65,13 @@ func swapBits(x, y *Bitstring, w, mask uint64) {
 // It's like Equals but only compares the [off, off+length) range. EqualRange
 // returns false if this range is not defined on both bitstrings.
 func EqualRange(bs1, bs2 *Bitstring, off, len int) bool {
+	mustNotNegative(off, len)
 	if off+len-1 >= bs1.length || off+len-1 >= bs2.length {
 		return false
 	}
+	if len == 0 {
+		return true
+	}
 
 	// Compare bits in the first word.
 	start, l := uint64(off), uint64(len)
@@ -88,11 +102,80 @@ func EqualRange(bs1, bs2 *Bitstring, off, len int) bool {
 	return true
 }
 
+// EqualRangeAt compares n bits of a, starting at offset aOff, with n bits of
+// b, starting at offset bOff.
+//
+// It's like EqualRange but the ranges may start at different offsets.
+// EqualRangeAt returns false if [aOff, aOff+n) is not defined on a or if [bOff,
+// bOff+n) is not defined on b.
+func EqualRangeAt(a *Bitstring, aOff int, b *Bitstring, bOff, n int) bool {
+	if aOff < 0 || bOff < 0 || n < 0 || aOff+n > a.length || bOff+n > b.length {
+		return false
+	}
+
+	// Compare 64 bits at a time.
+	i := 0
+	for ; i+64 <= n; i += 64 {
+		if a.Uint64(aOff+i) != b.Uint64(bOff+i) {
+			return false
+		}
+	}
+
+	// Compare the remaining bits.
+	if i < n {
+		return a.Uintn(aOff+i, n-i) == b.Uintn(bOff+i, n-i)
+	}
+	return true
+}
+
+// CopyBits copies n bits from src, starting at offset srcOff, to dst, starting
+// at offset dstOff. CopyBits doesn't allocate.
+//
+// dst and src may be the same Bitstring and the ranges may overlap, in which
+// case CopyBits behaves as if the source bits were first copied to a temporary
+// buffer.
+//
+// The ranges [srcOff, srcOff+n) of src and [dstOff, dstOff+n) of dst must exist
+// or CopyBits has undefined behavior.
+func CopyBits(dst *Bitstring, dstOff int, src *Bitstring, srcOff, n int) {
+	src.mustExistRange(srcOff, n)
+	dst.mustExistRange(dstOff, n)
+
+	if dst == src && dstOff > srcOff && dstOff < srcOff+n {
+		// The destination range overlaps the end of the source range, so we
+		// copy backwards in order to read source bits before they get
+		// overwritten.
+		i := n
+		for ; i >= 64; i -= 64 {
+			dst.SetUint64(dstOff+i-64, src.Uint64(srcOff+i-64))
+		}
+		if i > 0 {
+			dst.SetUintn(dstOff, i, src.Uintn(srcOff, i))
+		}
+		return
+	}
+
+	// Copy 64 bits at a time.
+	i := 0
+	for ; i+64 <= n; i += 64 {
+		dst.SetUint64(dstOff+i, src.Uint64(srcOff+i))
+	}
+
+	// Copy the remaining bits.
+	if i < n {
+		dst.SetUintn(dstOff+i, n-i, src.Uintn(srcOff+i, n-i))
+	}
+}
+
 // SetRange sets a range of bits (sets all bits to 1).
 //
 // The range [off, off+len) must exist or SetBitRange has undefined behavior.
 func (bs *Bitstring) SetRange(off, len int) {
-	bs.mustExist(off + len - 1)
+	bs.mustExistRange(off, len)
+
+	if len == 0 {
+		return
+	}
 
 	// Set bits in the first word.
 	start, l := uint64(off), uint64(len)
@@ -114,13 +197,18 @@ func (bs *Bitstring) SetRange(off, len int) {
 	if remain != 0 {
 		bs.data[i] |= lomask(remain)
 	}
+	bs.mustHaveClearPadding()
 }
 
 // ClearRange clears a range of bits (sets all bits to 0).
 //
 // The range [off, off+length) must exist or ClearRange has undefined behavior.
 func (bs *Bitstring) ClearRange(off, len int) {
-	bs.mustExist(off + len - 1)
+	bs.mustExistRange(off, len)
+
+	if len == 0 {
+		return
+	}
 
 	// Clear bits in the first word.
 	start, l := uint64(off), uint64(len)
@@ -142,13 +230,18 @@ func (bs *Bitstring) ClearRange(off, len int) {
 	if remain != 0 {
 		bs.data[i] &= himask(remain)
 	}
+	bs.mustHaveClearPadding()
 }
 
 // FlipRange flips a range of bits (flips the value of every bit).
 //
 // The range [off, off+len) must exist or FlipRange has undefined behavior.
 func (bs *Bitstring) FlipRange(off, len int) {
-	bs.mustExist(off + len - 1)
+	bs.mustExistRange(off, len)
+
+	if len == 0 {
+		return
+	}
 
 	// Flip bits in the first word.
 	start, l := uint64(off), uint64(len)
@@ -170,35 +263,132 @@ func (bs *Bitstring) FlipRange(off, len int) {
 	if remain != 0 {
 		bs.data[i] ^= lomask(remain)
 	}
+	bs.mustHaveClearPadding()
 }
 
-// CopyRange returns a new Bitstring with a copy of the bits in the [off,
-// off+len] range.
-func (bs *Bitstring) CopyRange(off, len int) *Bitstring {
-	bs.mustExist(off + len - 1)
+// InsertZeroes inserts n zero bits at offset off, growing bs by n bits. The
+// bits in the [off, bs.Len()) range are moved by n bits towards the most
+// significant end.
+//
+// off must be in the [0, bs.Len()] range or InsertZeroes has undefined
+// behavior.
+func (bs *Bitstring) InsertZeroes(off, n int) {
+	bs.mustExistRange(off, 0)
+	mustNotNegative(off, n)
+	if n == 0 {
+		return
+	}
 
-	ret := New(len)
+	bs.insertGap(off, n)
+	bs.ClearRange(off, n)
+}
+
+// InsertRange inserts all the bits of src at offset off, growing bs by
+// src.Len() bits. The bits in the [off, bs.Len()) range are moved by src.Len()
+// bits towards the most significant end. src may be bs.
+//
+// off must be in the [0, bs.Len()] range or InsertRange has undefined
+// behavior.
+func (bs *Bitstring) InsertRange(off int, src *Bitstring) {
+	bs.mustExistRange(off, 0)
+	if src.length == 0 {
+		return
+	}
+	if src == bs {
+		src = src.Clone()
+	}
+
+	bs.insertGap(off, src.length)
+	CopyBits(bs, off, src, 0, src.length)
+}
+
+// DeleteRange removes the bits in the [off, off+len) range, shrinking bs by
+// len bits. The bits in the [off+len, bs.Len()) range are moved by len bits
+// towards the least significant end.
+//
+// The range [off, off+len) must exist or DeleteRange has undefined behavior.
+func (bs *Bitstring) DeleteRange(off, len int) {
+	bs.mustExistRange(off, len)
+
+	if len == 0 {
+		return
+	}
+
+	// Shift the whole words from the one containing off, then restore the bits
+	// of that word that are below off.
+	w := wordoffset(uint64(off))
+	first := bs.data[w]
+	shr(bs.data[w:], bs.data[w:], uint64(len))
+	bs.data[w] = transferbits(bs.data[w], first, lomask(bitoffset(uint64(off))))
+
+	bs.Truncate(bs.length - len)
+	bs.mustHaveClearPadding()
+}
+
+// insertGap grows bs by n bits and moves the bits in the [off, bs.Len()) range
+// by n bits towards the most significant end. The content of the [off, off+n)
+// gap is undefined after insertGap.
+func (bs *Bitstring) insertGap(off, n int) {
+	bs.Resize(bs.length + n)
+
+	// Shift the whole words from the one containing off, then restore the bits
+	// of that word that are below off.
+	w := wordoffset(uint64(off))
+	first := bs.data[w]
+	shl(bs.data[w:], bs.data[w:], uint64(n))
+	bs.data[w] = transferbits(bs.data[w], first, lomask(bitoffset(uint64(off))))
+	bs.mustHaveClearPadding()
+}
 
-	// Copy the bits in the first word.
+// OnesCountRange counts the number of one bits in the [off, off+len) range.
+//
+// The range [off, off+len) must exist or OnesCountRange has undefined
+// behavior.
+func (bs *Bitstring) OnesCountRange(off, len int) int {
+	bs.mustExistRange(off, len)
+
+	if len == 0 {
+		return 0
+	}
+
+	// Count bits in the first word.
 	start, l := uint64(off), uint64(len)
 	i := wordoffset(start)
 	start = bitoffset(start)
 	end := minuint(start+l, 64)
-	mask := mask(start, end)
-	ret.data[0] = bs.data[i] & mask
+	count := bits.OnesCount64(bs.data[i] & mask(start, end))
 	i++
 
-	// Copy all words but the last one.
+	// Count bits in all words but the last one.
 	remain := l - (end - start)
-	j := i + (remain / 64)
-	n := copy(ret.data[1:], bs.data[i:j])
-	remain -= uint64(n) * 64
+	for remain > 64 {
+		count += bits.OnesCount64(bs.data[i])
+		remain -= 64
+		i++
+	}
 
-	// Copy bits in the last word.
+	// Count bits in the last word.
 	if remain != 0 {
-		mask := lomask(remain)
-		ret.data[n+1] = bs.data[j] & mask
+		count += bits.OnesCount64(bs.data[i] & lomask(remain))
 	}
 
+	return count
+}
+
+// ZeroesCountRange counts the number of zero bits in the [off, off+len) range.
+//
+// The range [off, off+len) must exist or ZeroesCountRange has undefined
+// behavior.
+func (bs *Bitstring) ZeroesCountRange(off, len int) int {
+	return len - bs.OnesCountRange(off, len)
+}
+
+// CopyRange returns a new Bitstring with a copy of the bits in the [off,
+// off+len) range.
+func (bs *Bitstring) CopyRange(off, len int) *Bitstring {
+	bs.mustExistRange(off, len)
+
+	ret := New(len)
+	CopyBits(ret, 0, bs, off, len)
 	return ret
 }
@@ -1,6 +1,8 @@
 package bitstring
 
 import (
+	"fmt"
+	"math/rand"
 	"strings"
 	"testing"
 
@@ -575,3 +577,182 @@ func TestCopyRange(t *testing.T) {
 	bs1 = bs.CopyRange(0, 1029)
 	assert.Equal(t, _1029_ones, bs1.String())
 }
+
+func TestOnesCountRange(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bs := Random(300, rng)
+	s := bs.String()
+
+	for _, off := range []int{0, 1, 3, 63, 64, 65, 128, 200, 299} {
+		for _, length := range []int{1, 2, 31, 63, 64, 65, 100, 128, 129, 236} {
+			if off+length > bs.Len() {
+				continue
+			}
+			t.Run(fmt.Sprintf("off=%d,len=%d", off, length), func(t *testing.T) {
+				// The range [off, off+length) in the string representation.
+				rs := s[len(s)-off-length : len(s)-off]
+				ones := strings.Count(rs, "1")
+
+				assert.Equal(t, ones, bs.OnesCountRange(off, length))
+				assert.Equal(t, length-ones, bs.ZeroesCountRange(off, length))
+			})
+		}
+	}
+}
+
+func TestInsertDeleteRange(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	for _, length := range []int{0, 1, 7, 63, 64, 65, 130, 300} {
+		for _, off := range []int{0, 1, 5, 63, 64, 65, 129, length} {
+			if off > length {
+				continue
+			}
+			for _, n := range []int{0, 1, 3, 63, 64, 65, 200} {
+				t.Run(fmt.Sprintf("len=%d,off=%d,n=%d", length, off, n), func(t *testing.T) {
+					bs := Random(length, rng)
+					s := bs.String()
+
+					// Index off in the string representation.
+					head, tail := s[:len(s)-off], s[len(s)-off:]
+
+					got := bs.Clone()
+					got.InsertZeroes(off, n)
+					want, _ := NewFromString(head + strings.Repeat("0", n) + tail)
+					equalbits(t, got, want)
+
+					src := Random(n, rng)
+					got = bs.Clone()
+					got.InsertRange(off, src)
+					want, _ = NewFromString(head + src.String() + tail)
+					equalbits(t, got, want)
+
+					if off+n <= length {
+						got = bs.Clone()
+						got.DeleteRange(off, n)
+						want, _ = NewFromString(s[:len(s)-off-n] + tail)
+						equalbits(t, got, want)
+					}
+				})
+			}
+		}
+	}
+
+	t.Run("insert self", func(t *testing.T) {
+		bs, _ := NewFromString("1100")
+		bs.InsertRange(1, bs)
+		assert.Equal(t, "11011000", bs.String())
+	})
+}
+
+func TestCopyRangeOffset(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bs := Random(300, rng)
+	s := bs.String()
+
+	for _, off := range []int{0, 1, 3, 63, 64, 65, 128, 200} {
+		for _, length := range []int{1, 2, 31, 63, 64, 65, 100} {
+			got := bs.CopyRange(off, length)
+			want, _ := NewFromString(s[len(s)-off-length : len(s)-off])
+			equalbits(t, got, want)
+		}
+	}
+}
+
+func TestCopyBits(t *testing.T) {
+	// sub returns the [off, off+n) range of the string representation s.
+	sub := func(s string, off, n int) string {
+		return s[len(s)-off-n : len(s)-off]
+	}
+	// put replaces the [off, off+len(v)) range of the string representation s
+	// with v.
+	put := func(s string, off int, v string) string {
+		return s[:len(s)-off-len(v)] + v + s[len(s)-off:]
+	}
+
+	rng := rand.New(rand.NewSource(99))
+	offsets := []int{0, 1, 5, 63, 64, 65, 127, 130}
+	for _, srcOff := range offsets {
+		for _, dstOff := range offsets {
+			for _, n := range []int{0, 1, 7, 63, 64, 65, 128, 129, 170} {
+				t.Run(fmt.Sprintf("src=%d,dst=%d,n=%d", srcOff, dstOff, n), func(t *testing.T) {
+					src, dst := Random(300, rng), Random(310, rng)
+					ssrc, sdst := src.String(), dst.String()
+
+					CopyBits(dst, dstOff, src, srcOff, n)
+					want, _ := NewFromString(put(sdst, dstOff, sub(ssrc, srcOff, n)))
+					equalbits(t, dst, want)
+					assert.Equal(t, ssrc, src.String())
+					assert.True(t, EqualRangeAt(dst, dstOff, src, srcOff, n))
+
+					// Same bitstring, overlapping or not.
+					CopyBits(src, dstOff, src, srcOff, n)
+					want, _ = NewFromString(put(ssrc, dstOff, sub(ssrc, srcOff, n)))
+					equalbits(t, src, want)
+				})
+			}
+		}
+	}
+
+	t.Run("no allocations", func(t *testing.T) {
+		bs := Random(1000, rng)
+		allocs := testing.AllocsPerRun(10, func() {
+			CopyBits(bs, 3, bs, 100, 800)
+			CopyBits(bs, 100, bs, 3, 800)
+		})
+		assert.Zero(t, allocs)
+	})
+}
+
+func TestEqualRangeAt(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	a := Random(300, rng)
+
+	for _, aOff := range []int{0, 1, 63, 64, 100} {
+		for _, bOff := range []int{0, 2, 64, 65, 77} {
+			for _, n := range []int{1, 30, 64, 65, 130, 200} {
+				b := Random(bOff+n+10, rng)
+				CopyBits(b, bOff, a, aOff, n)
+				assert.True(t, EqualRangeAt(a, aOff, b, bOff, n))
+				assert.True(t, EqualRangeAt(b, bOff, a, aOff, n))
+
+				// Flip a single bit of the range.
+				i := rng.Intn(n)
+				b.FlipBit(bOff + i)
+				assert.False(t, EqualRangeAt(a, aOff, b, bOff, n))
+
+				// Range not defined on b.
+				assert.False(t, EqualRangeAt(a, aOff, b, bOff+11, n))
+
+				// Negative offsets or length.
+				assert.False(t, EqualRangeAt(a, -1, b, bOff, n))
+				assert.False(t, EqualRangeAt(a, aOff, b, -64, n))
+				assert.False(t, EqualRangeAt(a, aOff, b, bOff, -1))
+			}
+		}
+	}
+}
+
+func TestEmptyRange(t *testing.T) {
+	// Empty ranges are valid at any offset in [0, Len()], including at the
+	// end of a bitstring whose length is a multiple of 64.
+	for _, length := range []int{0, 1, 64, 65, 128} {
+		for _, off := range []int{0, length / 2, length} {
+			bs := Random(length, rand.New(rand.NewSource(99)))
+			want := bs.Clone()
+			other := Random(length, rand.New(rand.NewSource(98)))
+
+			bs.SetRange(off, 0)
+			bs.ClearRange(off, 0)
+			bs.FlipRange(off, 0)
+			SwapRange(bs, other, off, 0)
+			bs.DeleteRange(off, 0)
+			bs.InsertZeroes(off, 0)
+			equalbits(t, bs, want)
+
+			assert.Equal(t, 0, bs.OnesCountRange(off, 0))
+			assert.Equal(t, 0, bs.ZeroesCountRange(off, 0))
+			assert.True(t, EqualRange(bs, other, off, 0))
+			assert.Equal(t, 0, bs.CopyRange(off, 0).Len())
+		}
+	}
+}
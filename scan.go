@@ -0,0 +1,126 @@
+package bitstring
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Scan implements the fmt.Scanner interface, so that a Bitstring can be read
+// with fmt.Scan, fmt.Sscanf, fmt.Fscan and friends. Leading spaces are
+// skipped, then the longest run of characters that can be part of a literal is
+// read, up to the width if any. As for integers, %b, %o, %x and %X stop at the
+// first character that is not a digit of their base, so that "10102" scanned
+// with %b reads 1010 and leaves 2 unread. It supports the following verbs:
+//
+//	%b	binary digits, with an optional 0b or 0B prefix
+//	%o	octal digits, with an optional 0o or 0O prefix
+//	%x %X	hexadecimal digits, with an optional 0x or 0X prefix
+//	%s %v	any literal accepted by Parse
+//
+// Since spaces separate the values read by fmt scanning functions, digits can
+// only be separated with '_'. The length of the scanned Bitstring is computed
+// as in Parse.
+func (bs *Bitstring) Scan(state fmt.ScanState, verb rune) error {
+	var shift int
+	switch verb {
+	case 'b':
+		shift = 1
+	case 'o':
+		shift = 3
+	case 'x', 'X':
+		shift = 4
+	case 's', 'v':
+	default:
+		return fmt.Errorf("bad verb '%%%c' for Bitstring", verb)
+	}
+
+	state.SkipSpace()
+	width, ok := state.Width()
+	if !ok {
+		width = -1
+	}
+	accept := isLiteralRune
+	if shift != 0 {
+		accept = digitRunes(verb, shift)
+	}
+	tok, err := state.Token(false, func(r rune) bool {
+		if width == 0 {
+			return false
+		}
+		width--
+		return accept(r)
+	})
+	if err != nil {
+		return err
+	}
+	if len(tok) == 0 {
+		if _, _, err := state.ReadRune(); err == io.EOF {
+			return io.EOF
+		}
+		state.UnreadRune()
+		return errors.New("expected bitstring literal")
+	}
+
+	s := string(tok)
+	var dec *Bitstring
+	if shift == 0 {
+		dec, err = Parse(s)
+	} else {
+		dec, err = parseDigits(s, prefixLen(s, verb), shift)
+	}
+	if err != nil {
+		return err
+	}
+	*bs = *dec
+	return nil
+}
+
+// isLiteralRune reports whether r can be part of a literal accepted by Scan.
+func isLiteralRune(r rune) bool {
+	return digitValue(r) < 16 || strings.ContainsRune("oOxXhH'_", r)
+}
+
+// digitRunes returns a function reporting whether r can be the next rune of a
+// literal in base 1<<shift scanned with verb, that is a digit, a '_' separator
+// or the letter of the prefix corresponding to verb, right after a leading 0.
+func digitRunes(verb rune, shift int) func(r rune) bool {
+	n, zero := 0, false
+	return func(r rune) bool {
+		n++
+		switch {
+		case r == '_':
+			return true
+		case digitValue(r) < 1<<shift:
+			if n == 1 {
+				zero = r == '0'
+			}
+			return true
+		case n == 2 && zero:
+			return isPrefix(r, verb)
+		}
+		return false
+	}
+}
+
+// prefixLen returns the length of the base prefix of s, that is 2 if s starts
+// with the prefix corresponding to verb, 0 otherwise.
+func prefixLen(s string, verb rune) int {
+	if len(s) >= 2 && s[0] == '0' && isPrefix(rune(s[1]), verb) {
+		return 2
+	}
+	return 0
+}
+
+// isPrefix reports whether c is the letter of the base prefix corresponding to
+// verb.
+func isPrefix(c, verb rune) bool {
+	switch {
+	case verb == 'b' && (c == 'b' || c == 'B'),
+		verb == 'o' && (c == 'o' || c == 'O'),
+		(verb == 'x' || verb == 'X') && (c == 'x' || c == 'X'):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package bitstring
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		format string
+		input  string
+		want   string
+	}{
+		{"%v", "1010", "1010"},
+		{"%v", "  0x1f", "00011111"},
+		{"%v", "12'habc", "101010111100"},
+		{"%s", "0b1_0", "10"},
+		{"%b", "1010", "1010"},
+		{"%b", "0b1010", "1010"},
+		{"%b", "0B1010_0101", "10100101"},
+		{"%o", "17", "001111"},
+		{"%o", "0o17", "001111"},
+		{"%x", "abc", "101010111100"},
+		{"%x", "0xabc", "101010111100"},
+		{"%X", "0XABC", "101010111100"},
+		{"%x", "0b", "00001011"},
+		{"%3b", "10101", "101"},
+		{"%4x", "0xab12", "10101011"},
+		{"%b,", "101,", "101"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.input, func(t *testing.T) {
+			var got Bitstring
+			_, err := fmt.Sscanf(tt.input, tt.format, &got)
+			require.NoError(t, err)
+			want, _ := NewFromString(tt.want)
+			equalbits(t, &got, want)
+		})
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	tests := []struct {
+		format  string
+		input   string
+		wantErr string
+	}{
+		{"%v", "0b102", "illegal character at position 4: U+0032 '2'"},
+		{"%b", "2", "expected bitstring literal"},
+		{"%x", "g", "expected bitstring literal"},
+		{"%2x", "0xab", "missing digits at position 2"},
+		{"%v", "7'hff", "value overflows 7 bits"},
+		{"%v", "4294967296'b1", fmt.Sprintf("size exceeds MaxParseLen (%d)", MaxParseLen)},
+		{"%b", "-101", "expected bitstring literal"},
+		{"%d", "101", "bad verb '%d' for Bitstring"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.input, func(t *testing.T) {
+			var got Bitstring
+			_, err := fmt.Sscanf(tt.input, tt.format, &got)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestScanStopsAtNonDigit(t *testing.T) {
+	// Like integers, %b, %o and %x stop at the first rune that's not a digit
+	// of their base, or a prefix right after a leading 0.
+	tests := []struct {
+		format string
+		input  string
+		want   string
+		rest   string
+	}{
+		{"%b", "10102", "1010", "2"},
+		{"%b", "0b1x", "1", "x"},
+		{"%b", "1b0", "1", "b0"},
+		{"%o", "0x17", "000", "x17"},
+		{"%o", "178", "001111", "8"},
+		{"%x", "0xfg", "1111", "g"},
+		{"%x", "12h", "00010010", "h"},
+		{"%x", "0_x1", "0000", "x1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.input, func(t *testing.T) {
+			var (
+				got  Bitstring
+				rest string
+			)
+			n, err := fmt.Sscanf(tt.input, tt.format+"%s", &got, &rest)
+			require.NoError(t, err)
+			assert.Equal(t, 2, n)
+			want, _ := NewFromString(tt.want)
+			equalbits(t, &got, want)
+			assert.Equal(t, tt.rest, rest)
+		})
+	}
+}
+
+func TestScanStream(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	var (
+		sb   strings.Builder
+		want []*Bitstring
+	)
+	for _, length := range []int{1, 8, 63, 64, 65, 200} {
+		bs := Random(length, rng)
+		want = append(want, bs)
+		fmt.Fprintf(&sb, "%d'h%x\t%v\n", length, bs, bs)
+	}
+
+	r := strings.NewReader(sb.String())
+	for _, bs := range want {
+		var hex, bin Bitstring
+		n, err := fmt.Fscan(r, &hex, &bin)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		equalbits(t, &hex, bs)
+		equalbits(t, &bin, bs)
+	}
+
+	// fmt reports io.EOF returned by a Scanner as io.ErrUnexpectedEOF.
+	var bs Bitstring
+	_, err := fmt.Fscan(r, &bs)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func ExampleBitstring_Scan() {
+	var a, b Bitstring
+	fmt.Sscanf("0b1010_0101 12'habc", "%b %v", &a, &b)
+	fmt.Println(&a, &b)
+	// Output: 10100101 101010111100
+}
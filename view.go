@@ -0,0 +1,262 @@
+package bitstring
+
+// View is a window over a range of bits of a Bitstring, its parent. A View
+// doesn't copy any bit, it shares the storage of its parent: the bit i of a
+// View is the bit off+i of its parent, where off is the offset of the View.
+// Changes made through a View are visible in the parent, and vice versa.
+//
+// A View remains valid as long as its range exists on the parent. Behavior is
+// undefined if the parent is shrunk below the end of the View range.
+type View struct {
+	bs     *Bitstring
+	off    int
+	length int
+}
+
+// Slice returns a View over the bits in the [off, off+len) range of bs.
+//
+// The range [off, off+len) must exist or Slice has undefined behavior.
+func (bs *Bitstring) Slice(off, len int) View {
+	bs.mustExistRange(off, len)
+
+	return View{bs: bs, off: off, length: len}
+}
+
+// Slice returns a View over the bits in the [off, off+len) range of v. The
+// returned View shares the same parent as v.
+//
+// The range [off, off+len) must exist or Slice has undefined behavior.
+func (v View) Slice(off, len int) View {
+	v.mustExistRange(off, len)
+
+	return View{bs: v.bs, off: v.off + off, length: len}
+}
+
+// Len returns the length of v, that is the number of bits it contains.
+func (v View) Len() int { return v.length }
+
+// Offset returns the offset of the first bit of v in its parent.
+func (v View) Offset() int { return v.off }
+
+// Parent returns the Bitstring v is a View of.
+func (v View) Parent() *Bitstring { return v.bs }
+
+// Clone returns a new Bitstring holding a copy of the bits of v.
+func (v View) Clone() *Bitstring { return v.bs.CopyRange(v.off, v.length) }
+
+// String returns a string representation of v in big endian order.
+func (v View) String() string { return v.Clone().String() }
+
+/* single bit */
+
+// Bit returns a boolean indicating whether the bit at index i is set or not.
+func (v View) Bit(i int) bool {
+	v.mustExist(i)
+	return v.bs.Bit(v.off + i)
+}
+
+// SetBit sets the bit at index i.
+func (v View) SetBit(i int) {
+	v.mustExist(i)
+	v.bs.SetBit(v.off + i)
+}
+
+// ClearBit clears the bit at index i.
+func (v View) ClearBit(i int) {
+	v.mustExist(i)
+	v.bs.ClearBit(v.off + i)
+}
+
+// FlipBit flips (i.e toggles) the bit at index i.
+func (v View) FlipBit(i int) {
+	v.mustExist(i)
+	v.bs.FlipBit(v.off + i)
+}
+
+/* unsigned integer get */
+
+// Uint8 interprets the 8 bits at offset off as an uint8 in big endian and
+// returns its value. Behavior is undefined if there aren't enough bits.
+func (v View) Uint8(off int) uint8 {
+	v.mustExistRange(off, 8)
+	return v.bs.Uint8(v.off + off)
+}
+
+// Uint16 interprets the 16 bits at offset off as an uint16 in big endian and
+// returns its value. Behavior is undefined if there aren't enough bits.
+func (v View) Uint16(off int) uint16 {
+	v.mustExistRange(off, 16)
+	return v.bs.Uint16(v.off + off)
+}
+
+// Uint32 interprets the 32 bits at offset off as an uint32 in big endian and
+// returns its value. Behavior is undefined if there aren't enough bits.
+func (v View) Uint32(off int) uint32 {
+	v.mustExistRange(off, 32)
+	return v.bs.Uint32(v.off + off)
+}
+
+// Uint64 interprets the 64 bits at offset off as an uint64 in big endian and
+// returns its value. Behavior is undefined if there aren't enough bits.
+func (v View) Uint64(off int) uint64 {
+	v.mustExistRange(off, 64)
+	return v.bs.Uint64(v.off + off)
+}
+
+// Uintn interprets the n bits at offset off as an n-bit unsigned integer in big
+// endian and returns its value. Behavior is undefined if there aren't enough
+// bits. Panics if nbits is greater than 64.
+func (v View) Uintn(off, n int) uint64 {
+	v.mustExistRange(off, n)
+	return v.bs.Uintn(v.off+off, n)
+}
+
+/* unsigned integer set */
+
+// SetUint8 sets the 8 bits at offset off with the given uint8 value, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (v View) SetUint8(off int, val uint8) {
+	v.mustExistRange(off, 8)
+	v.bs.SetUint8(v.off+off, val)
+}
+
+// SetUint16 sets the 16 bits at offset off with the given uint16 value, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (v View) SetUint16(off int, val uint16) {
+	v.mustExistRange(off, 16)
+	v.bs.SetUint16(v.off+off, val)
+}
+
+// SetUint32 sets the 32 bits at offset off with the given uint32 value, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (v View) SetUint32(off int, val uint32) {
+	v.mustExistRange(off, 32)
+	v.bs.SetUint32(v.off+off, val)
+}
+
+// SetUint64 sets the 64 bits at offset off with the given uint64 value, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (v View) SetUint64(off int, val uint64) {
+	v.mustExistRange(off, 64)
+	v.bs.SetUint64(v.off+off, val)
+}
+
+// SetUintn sets the n bits at offset off with the given n-bit unsigned integer
+// in big endian. Behavior is undefined if there aren't enough bits. Panics if
+// nbits is greater than 64.
+func (v View) SetUintn(off, n int, val uint64) {
+	v.mustExistRange(off, n)
+	v.bs.SetUintn(v.off+off, n, val)
+}
+
+/* signed get */
+
+// Int8 interprets the 8 bits at offset off as an int8 in big endian and
+// returns its value. Behavior is undefined if there aren't enough bits.
+func (v View) Int8(off int) int8 { return int8(v.Uint8(off)) }
+
+// Int16 interprets the 16 bits at offset off as an int16 in big endian and
+// returns its value. Behavior is undefined if there aren't enough bits.
+func (v View) Int16(off int) int16 { return int16(v.Uint16(off)) }
+
+// Int32 interprets the 32 bits at offset off as an int32 in big endian and
+// returns its value. Behavior is undefined if there aren't enough bits.
+func (v View) Int32(off int) int32 { return int32(v.Uint32(off)) }
+
+// Int64 interprets the 64 bits at offset off as an int64 in big endian and
+// returns its value. Behavior is undefined if there aren't enough bits.
+func (v View) Int64(off int) int64 { return int64(v.Uint64(off)) }
+
+// Intn interprets the n bits at offset off as an n-bit signed integer in big
+// endian and returns its value. Behavior is undefined if there aren't enough
+// bits. Panics if nbits is greater than 64.
+func (v View) Intn(off, n int) int64 {
+	v.mustExistRange(off, n)
+	return v.bs.Intn(v.off+off, n)
+}
+
+/* signed integer set */
+
+// SetInt8 sets the 8 bits at offset off with the given int8 value, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (v View) SetInt8(off int, val int8) { v.SetUint8(off, uint8(val)) }
+
+// SetInt16 sets the 16 bits at offset off with the given int16 value, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (v View) SetInt16(off int, val int16) { v.SetUint16(off, uint16(val)) }
+
+// SetInt32 sets the 32 bits at offset off with the given int32 value, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (v View) SetInt32(off int, val int32) { v.SetUint32(off, uint32(val)) }
+
+// SetInt64 sets the 64 bits at offset off with the given int64 value, in big
+// endian. Behavior is undefined if there aren't enough bits.
+func (v View) SetInt64(off int, val int64) { v.SetUint64(off, uint64(val)) }
+
+// SetIntn sets the n bits at offset off with the given n-bit signed integer in
+// big endian. Behavior is undefined if there aren't enough bits. Panics if
+// nbits is greater than 64.
+func (v View) SetIntn(off, n int, val int64) { v.SetUintn(off, n, uint64(val)) }
+
+// SetIntnChecked is like SetIntn but first checks that val can be represented
+// as an n-bit signed integer. If it can't, v is left untouched and an error
+// wrapping ErrOverflow is returned. Panics if nbits is greater than 64.
+func (v View) SetIntnChecked(off, n int, val int64) error {
+	if err := checkIntn(n, val); err != nil {
+		return err
+	}
+	v.SetIntn(off, n, val)
+	return nil
+}
+
+/* ranges */
+
+// SetRange sets a range of bits (sets all bits to 1).
+//
+// The range [off, off+len) must exist or SetRange has undefined behavior.
+func (v View) SetRange(off, len int) {
+	v.mustExistRange(off, len)
+	v.bs.SetRange(v.off+off, len)
+}
+
+// ClearRange clears a range of bits (sets all bits to 0).
+//
+// The range [off, off+len) must exist or ClearRange has undefined behavior.
+func (v View) ClearRange(off, len int) {
+	v.mustExistRange(off, len)
+	v.bs.ClearRange(v.off+off, len)
+}
+
+// FlipRange flips a range of bits (flips the value of every bit).
+//
+// The range [off, off+len) must exist or FlipRange has undefined behavior.
+func (v View) FlipRange(off, len int) {
+	v.mustExistRange(off, len)
+	v.bs.FlipRange(v.off+off, len)
+}
+
+// CopyRange returns a new Bitstring with a copy of the bits in the [off,
+// off+len) range.
+func (v View) CopyRange(off, len int) *Bitstring {
+	v.mustExistRange(off, len)
+	return v.bs.CopyRange(v.off+off, len)
+}
+
+// OnesCount counts the number of one bits.
+func (v View) OnesCount() int {
+	if v.length == 0 {
+		return 0
+	}
+	return v.bs.OnesCountRange(v.off, v.length)
+}
+
+// ZeroesCount counts the number of zero bits.
+func (v View) ZeroesCount() int {
+	return v.length - v.OnesCount()
+}
+
+// Equals returns true if v and other have the same length and each bit are
+// identical.
+func (v View) Equals(other View) bool {
+	return v.length == other.length && EqualRangeAt(v.bs, v.off, other.bs, other.off, v.length)
+}
@@ -0,0 +1,98 @@
+package bitstring
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewRead(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bs := Random(300, rng)
+	s := bs.String()
+
+	const off, length = 37, 200
+	v := bs.Slice(off, length)
+	assert.Equal(t, length, v.Len())
+	assert.Equal(t, off, v.Offset())
+	assert.True(t, v.Parent() == bs)
+	assert.Equal(t, s[len(s)-off-length:len(s)-off], v.String())
+
+	for i := 0; i < length; i++ {
+		assert.Equal(t, bs.Bit(off+i), v.Bit(i))
+	}
+	for i := 0; i+64 <= length; i++ {
+		assert.Equal(t, bs.Uint8(off+i), v.Uint8(i))
+		assert.Equal(t, bs.Uint16(off+i), v.Uint16(i))
+		assert.Equal(t, bs.Uint32(off+i), v.Uint32(i))
+		assert.Equal(t, bs.Uint64(off+i), v.Uint64(i))
+		assert.Equal(t, bs.Uintn(off+i, 13), v.Uintn(i, 13))
+		assert.Equal(t, bs.Int8(off+i), v.Int8(i))
+		assert.Equal(t, bs.Int16(off+i), v.Int16(i))
+		assert.Equal(t, bs.Int32(off+i), v.Int32(i))
+		assert.Equal(t, bs.Int64(off+i), v.Int64(i))
+		assert.Equal(t, bs.Intn(off+i, 13), v.Intn(i, 13))
+	}
+
+	assert.Equal(t, bs.OnesCountRange(off, length), v.OnesCount())
+	assert.Equal(t, bs.ZeroesCountRange(off, length), v.ZeroesCount())
+	equalbits(t, v.Clone(), bs.CopyRange(off, length))
+	equalbits(t, v.CopyRange(3, 70), bs.CopyRange(off+3, 70))
+
+	// Sub-view.
+	sub := v.Slice(10, 100)
+	assert.Equal(t, off+10, sub.Offset())
+	assert.True(t, sub.Equals(bs.Slice(off+10, 100)))
+	assert.False(t, sub.Equals(bs.Slice(off+10, 99)))
+
+	// Reading through a View doesn't allocate.
+	var x uint32
+	allocs := testing.AllocsPerRun(10, func() {
+		x += v.Uint32(3) + uint32(v.Slice(5, 40).Uint16(1))
+	})
+	assert.Zero(t, allocs)
+	sink = x
+}
+
+func TestViewWrite(t *testing.T) {
+	bs := New(250)
+	v := bs.Slice(60, 150)
+
+	v.SetBit(0)
+	assert.True(t, bs.Bit(60))
+	v.FlipBit(1)
+	assert.True(t, bs.Bit(61))
+	v.ClearBit(0)
+	assert.False(t, bs.Bit(60))
+	v.ClearBit(1)
+
+	v.SetUint8(2, 0xff)
+	v.SetUint16(10, 0xffff)
+	v.SetUint32(26, 0xffffffff)
+	v.SetUint64(58, 0xffffffffffffffff)
+	assert.Equal(t, 8+16+32+64, bs.OnesCount())
+	assert.Equal(t, 8+16+32+64, bs.OnesCountRange(62, 120))
+	v.ClearRange(0, 150)
+	assert.Zero(t, bs.OnesCount())
+
+	v.SetInt8(2, -1)
+	v.SetInt16(10, -1)
+	v.SetInt32(26, -1)
+	v.SetInt64(58, -1)
+	assert.Equal(t, 8+16+32+64, bs.OnesCount())
+	v.FlipRange(0, 150)
+	assert.Equal(t, 150-(8+16+32+64), bs.OnesCount())
+	v.SetRange(0, 150)
+	assert.Equal(t, strings.Repeat("0", 40)+strings.Repeat("1", 150)+strings.Repeat("0", 60), bs.String())
+
+	v.SetUintn(140, 10, 0)
+	v.SetIntn(0, 5, 0)
+	assert.Equal(t, 135, bs.OnesCount())
+
+	// Writes through a sub-view are visible in the parent.
+	v.Slice(50, 10).ClearRange(0, 10)
+	assert.Equal(t, 125, bs.OnesCount())
+	assert.False(t, bs.Bit(110))
+}